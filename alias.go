@@ -0,0 +1,174 @@
+package fastrand
+
+import (
+	"math"
+	"math/bits"
+)
+
+// An AliasTable draws random indices in proportion to a fixed set of weights
+// in O(1) time per draw, using Walker's alias method as described by Vose. It
+// is safe for concurrent use by multiple goroutines.
+//
+// The table consists of one column per weight. Each column holds its own
+// index and an alias; a draw chooses a column uniformly and then returns
+// either the index or the alias depending on a second draw.
+type AliasTable struct {
+	alias []int
+
+	// In float mode, prob[i] is the probability that column i returns i
+	// rather than alias[i].
+	prob []float64
+
+	// In exact mode, each column has total slots, and cutoff[i] of them
+	// return i rather than alias[i].
+	cutoff []uint64
+	total  uint64
+}
+
+// NewAliasTable returns an AliasTable that draws index i with probability
+// weights[i]/sum(weights). The probabilities are subject to floating point
+// rounding; use NewAliasTableUint64 for exact probabilities. It panics if
+// weights is empty, if any weight is negative or not finite, or if all
+// weights are zero.
+func NewAliasTable(weights []float64) *AliasTable {
+	if len(weights) == 0 {
+		panic("fastrand: NewAliasTable called with no weights")
+	}
+	var sum float64
+	var heaviest int
+	for i, w := range weights {
+		if !(w >= 0) || math.IsInf(w, 0) {
+			panic("fastrand: invalid weight passed to NewAliasTable")
+		}
+		sum += w
+		if w > weights[heaviest] {
+			heaviest = i
+		}
+	}
+	if !(sum > 0) || math.IsInf(sum, 0) {
+		panic("fastrand: invalid total weight passed to NewAliasTable")
+	}
+
+	n := len(weights)
+	t := &AliasTable{
+		alias: make([]int, n),
+		prob:  make([]float64, n),
+	}
+	scaled := make([]float64, n)
+	var small, large []int
+	for i, w := range weights {
+		// Dividing first keeps huge weights from overflowing.
+		scaled[i] = w / sum * float64(n)
+		if scaled[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.prob[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] -= 1 - scaled[s]
+		if scaled[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// Whatever remains is full, up to rounding error. If rounding exhausted
+	// the large columns early, a zero weight may remain; it must never be
+	// drawn, so its column goes to the heaviest weight instead.
+	for _, i := range append(small, large...) {
+		if weights[i] == 0 {
+			t.prob[i] = 0
+			t.alias[i] = heaviest
+			continue
+		}
+		t.prob[i] = 1
+		t.alias[i] = i
+	}
+	return t
+}
+
+// NewAliasTableUint64 returns an AliasTable that draws index i with
+// probability exactly weights[i]/sum(weights). It panics if weights is empty,
+// if all weights are zero, or if sum(weights)*len(weights) overflows a
+// uint64.
+func NewAliasTableUint64(weights []uint64) *AliasTable {
+	if len(weights) == 0 {
+		panic("fastrand: NewAliasTableUint64 called with no weights")
+	}
+	var total uint64
+	for _, w := range weights {
+		var carry uint64
+		total, carry = bits.Add64(total, w, 0)
+		if carry != 0 {
+			panic("fastrand: total weight passed to NewAliasTableUint64 overflows")
+		}
+	}
+	if total == 0 {
+		panic("fastrand: total weight passed to NewAliasTableUint64 is 0")
+	}
+	n := uint64(len(weights))
+	if hi, _ := bits.Mul64(total, n); hi != 0 {
+		panic("fastrand: total weight passed to NewAliasTableUint64 overflows")
+	}
+
+	// Scale each weight by n, so that each column holds exactly total slots.
+	t := &AliasTable{
+		alias:  make([]int, n),
+		cutoff: make([]uint64, n),
+		total:  total,
+	}
+	scaled := make([]uint64, n)
+	var small, large []int
+	for i, w := range weights {
+		scaled[i] = w * n
+		if scaled[i] < total {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		t.cutoff[s] = scaled[s]
+		t.alias[s] = l
+		scaled[l] -= total - scaled[s]
+		if scaled[l] < total {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// With integer arithmetic, every remaining column is exactly full.
+	for _, i := range append(small, large...) {
+		t.cutoff[i] = total
+		t.alias[i] = i
+	}
+	return t
+}
+
+// Len returns the number of weights in the table.
+func (t *AliasTable) Len() int {
+	return len(t.alias)
+}
+
+// Sample returns a random index, chosen in proportion to the table's weights.
+func (t *AliasTable) Sample() int {
+	if t.cutoff != nil {
+		// Choose a column and a slot within it with a single draw.
+		x := Uint64n(uint64(len(t.cutoff)) * t.total)
+		col, slot := x/t.total, x%t.total
+		if slot < t.cutoff[col] {
+			return int(col)
+		}
+		return t.alias[col]
+	}
+	col := Intn(len(t.prob))
+	if Float64() < t.prob[col] {
+		return col
+	}
+	return t.alias[col]
+}
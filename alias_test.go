@@ -0,0 +1,177 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// chiSquare returns the chi-square statistic of the observed counts against
+// the expected counts. Categories with an expected count of zero must have
+// an observed count of zero, and are otherwise ignored.
+func chiSquare(counts []int, expected []float64) float64 {
+	var stat float64
+	for i, e := range expected {
+		if e == 0 {
+			if counts[i] != 0 {
+				return math.Inf(1)
+			}
+			continue
+		}
+		d := float64(counts[i]) - e
+		stat += d * d / e
+	}
+	return stat
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkChiSquare draws iters samples from fn and fails the test if the
+// counts are not consistent with the given probabilities.
+func checkChiSquare(t *testing.T, iters int, probs []float64, fn func() int) {
+	t.Helper()
+	counts := make([]int, len(probs))
+	expected := make([]float64, len(probs))
+	df := -1
+	for i, p := range probs {
+		expected[i] = p * float64(iters)
+		if p > 0 {
+			df++
+		}
+	}
+	for i := 0; i < iters; i++ {
+		counts[fn()]++
+	}
+	if stat, crit := chiSquare(counts, expected), chiSquareCritical(df); stat > crit {
+		t.Errorf("chi-square statistic %.2f exceeds critical value %.2f; counts %v, expected %v", stat, crit, counts, expected)
+	}
+}
+
+// TestAliasTablePanics tests that the AliasTable constructors panic on
+// invalid weights.
+func TestAliasTablePanics(t *testing.T) {
+	for _, w := range [][]float64{
+		nil,
+		{0, 0},
+		{1, -1},
+		{1, math.NaN()},
+		{1, math.Inf(1)},
+		{math.MaxFloat64, math.MaxFloat64},
+	} {
+		if !panics(func() { NewAliasTable(w) }) {
+			t.Errorf("expected panic for weights %v", w)
+		}
+	}
+	for _, w := range [][]uint64{
+		nil,
+		{0, 0},
+		{math.MaxUint64, 1},
+		{math.MaxUint64 / 2, 1},
+	} {
+		if !panics(func() { NewAliasTableUint64(w) }) {
+			t.Errorf("expected panic for weights %v", w)
+		}
+	}
+	if panics(func() { NewAliasTableUint64([]uint64{math.MaxUint64}) }) {
+		t.Error("did not expect panic for a single maximal weight")
+	}
+}
+
+// TestAliasTableUint64Exact tests that an exact table assigns exactly
+// n*weights[i] of its slots to index i.
+func TestAliasTableUint64Exact(t *testing.T) {
+	for _, weights := range [][]uint64{
+		{1},
+		{1, 1},
+		{0, 1},
+		{1, 2, 3, 4, 0},
+		{7, 1, 1, 1, 1, 1, 1, 1},
+		{1e12, 1, 3, 5, 1e9},
+	} {
+		table := NewAliasTableUint64(weights)
+		slots := make([]uint64, len(weights))
+		for col := range weights {
+			slots[col] += table.cutoff[col]
+			slots[table.alias[col]] += table.total - table.cutoff[col]
+		}
+		for i, w := range weights {
+			if slots[i] != w*uint64(len(weights)) {
+				t.Errorf("weights %v: index %v has %v slots, expected %v", weights, i, slots[i], w*uint64(len(weights)))
+			}
+		}
+	}
+}
+
+// TestAliasTable tests that both kinds of AliasTable draw indices in
+// proportion to their weights.
+func TestAliasTable(t *testing.T) {
+	ints := []uint64{1, 2, 3, 4, 0, 10}
+	probs := []float64{0.05, 0.1, 0.15, 0.2, 0, 0.5}
+	checkChiSquare(t, 100000, probs, NewAliasTableUint64(ints).Sample)
+
+	floats := []float64{0.5, 1, 1.5, 2, 0, 5}
+	checkChiSquare(t, 100000, probs, NewAliasTable(floats).Sample)
+
+	// Huge weights with a finite sum must not overflow when scaled.
+	huge := []float64{8e307, 8e307, 0}
+	checkChiSquare(t, 100000, []float64{0.5, 0.5, 0}, NewAliasTable(huge).Sample)
+	huge = []float64{math.MaxFloat64 / 4, math.MaxFloat64 / 2, math.MaxFloat64 / 4}
+	checkChiSquare(t, 100000, []float64{0.25, 0.5, 0.25}, NewAliasTable(huge).Sample)
+
+	// A single weight must always be chosen.
+	table := NewAliasTable([]float64{3})
+	for i := 0; i < 100; i++ {
+		if table.Sample() != 0 {
+			t.Fatal("single-weight table returned nonzero index")
+		}
+	}
+	if table.Len() != 1 {
+		t.Errorf("expected Len of 1, got %v", table.Len())
+	}
+}
+
+// TestAliasTableZeroWeights tests that a float AliasTable never draws an
+// index with zero weight, even when rounding leaves columns unfilled.
+func TestAliasTableZeroWeights(t *testing.T) {
+	for _, weights := range [][]float64{
+		{1e300, 0, 1e-300},
+		{0, 0.1, 0.2, 0, 0.3, 0, 0.7},
+		{0, 1.0 / 3, 1.0 / 3, 1.0 / 3, 0},
+		{math.SmallestNonzeroFloat64, 0, 1},
+	} {
+		table := NewAliasTable(weights)
+		for col, p := range table.prob {
+			if weights[col] == 0 && p != 0 {
+				t.Errorf("weights %v: zero-weight column %v has probability %v", weights, col, p)
+			}
+			if p < 1 && weights[table.alias[col]] == 0 {
+				t.Errorf("weights %v: column %v aliases zero-weight index %v", weights, col, table.alias[col])
+			}
+		}
+		for i := 0; i < 10000; i++ {
+			if x := table.Sample(); weights[x] == 0 {
+				t.Fatalf("weights %v: drew zero-weight index %v", weights, x)
+			}
+		}
+	}
+}
+
+// BenchmarkAliasTable benchmarks drawing from a large exact AliasTable.
+func BenchmarkAliasTable(b *testing.B) {
+	weights := make([]uint64, 1000)
+	for i := range weights {
+		weights[i] = uint64(i + 1)
+	}
+	table := NewAliasTableUint64(weights)
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = table.Sample()
+	}
+}
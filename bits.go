@@ -0,0 +1,89 @@
+package fastrand
+
+import (
+	"encoding/binary"
+	"sync"
+)
+
+// Bits hands out random bits from a cache of generated words, so that many
+// small draws share the cost of a single call to Read. The zero value is
+// ready to use. A Bits must not be used by multiple goroutines at once; each
+// goroutine should have its own, or share a LockedBits instead.
+type Bits struct {
+	words [8]uint64 // one hash block worth of words
+	left  int       // number of unused entries at the end of words
+	cur   uint64    // the word currently being consumed
+	avail uint      // number of unused bits in cur
+}
+
+// refill loads the next cached word into cur, reading a new block of words if
+// necessary.
+func (b *Bits) refill() {
+	if b.left == 0 {
+		var buf [len(b.words) * 8]byte
+		Read(buf[:])
+		for i := range b.words {
+			b.words[i] = binary.LittleEndian.Uint64(buf[i*8:])
+		}
+		b.left = len(b.words)
+	}
+	b.cur = b.words[len(b.words)-b.left]
+	b.left--
+	b.avail = 64
+}
+
+// Uint returns a uniform random value in [0,2^n). It panics if n > 64.
+func (b *Bits) Uint(n uint) uint64 {
+	if n > 64 {
+		panic("fastrand: argument to Uint is > 64")
+	}
+	if n <= b.avail {
+		r := b.cur & (1<<n - 1)
+		b.cur >>= n
+		b.avail -= n
+		return r
+	}
+	// Take what remains of the current word, then the rest from the next.
+	r, have := b.cur, b.avail
+	b.refill()
+	need := n - have
+	r |= (b.cur & (1<<need - 1)) << have
+	b.cur >>= need
+	b.avail -= need
+	return r
+}
+
+// Bool returns a random bool.
+func (b *Bits) Bool() bool {
+	return b.Uint(1) == 1
+}
+
+// LockedBits is a Bits that is safe for concurrent use by multiple
+// goroutines.
+type LockedBits struct {
+	mu   sync.Mutex
+	bits Bits
+}
+
+// Uint returns a uniform random value in [0,2^n). It panics if n > 64.
+func (lb *LockedBits) Uint(n uint) uint64 {
+	lb.mu.Lock()
+	defer lb.mu.Unlock()
+	return lb.bits.Uint(n)
+}
+
+// Bool returns a random bool.
+func (lb *LockedBits) Bool() bool {
+	return lb.Uint(1) == 1
+}
+
+// globalBits is the shared cache used by Bool and Uint.
+var globalBits LockedBits
+
+// Bool returns a random bool. It is much cheaper than Intn(2), as it draws
+// from a shared cache of random bits.
+func Bool() bool { return globalBits.Bool() }
+
+// Uint returns a uniform random value in [0,2^n), drawn from a shared cache of
+// random bits. It panics if n > 64.
+func Uint(n uint) uint64 { return globalBits.Uint(n) }
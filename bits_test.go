@@ -0,0 +1,112 @@
+package fastrand
+
+import (
+	"sync"
+	"testing"
+)
+
+// TestBitsUintPanics tests that Uint panics if n > 64.
+func TestBitsUintPanics(t *testing.T) {
+	var b Bits
+	if !panics(func() { b.Uint(65) }) {
+		t.Error("expected panic for n > 64")
+	}
+	if panics(func() { b.Uint(64) }) {
+		t.Error("did not expect panic for n == 64")
+	}
+	if !panics(func() { Uint(65) }) {
+		t.Error("expected panic for n > 64")
+	}
+}
+
+// TestBitsUint tests that every width produces values in range, and that each
+// bit position is set about half the time, including draws that straddle
+// cached words.
+func TestBitsUint(t *testing.T) {
+	var b Bits
+	for n := uint(0); n <= 64; n++ {
+		const iters = 2000
+		var set [64]int
+		for i := 0; i < iters; i++ {
+			r := b.Uint(n)
+			if n < 64 && r >= 1<<n {
+				t.Fatalf("Uint(%v) returned %v", n, r)
+			}
+			for j := range set {
+				set[j] += int(r >> uint(j) & 1)
+			}
+		}
+		for j := uint(0); j < n; j++ {
+			if set[j] < iters*4/10 || set[j] > iters*6/10 {
+				t.Errorf("bit %v of Uint(%v) was set %v times out of %v", j, n, set[j], iters)
+			}
+		}
+	}
+}
+
+// TestBool tests the Bool function and the Bits and LockedBits variants.
+func TestBool(t *testing.T) {
+	const iters = 10000
+	var b Bits
+	var lb LockedBits
+	for _, fn := range []func() bool{Bool, b.Bool, lb.Bool} {
+		var trues int
+		for i := 0; i < iters; i++ {
+			if fn() {
+				trues++
+			}
+		}
+		if trues < iters*45/100 || trues > iters*55/100 {
+			t.Errorf("expected roughly %v trues, got %v", iters/2, trues)
+		}
+	}
+}
+
+// TestLockedBitsConcurrent checks that there are no race conditions when
+// using a LockedBits concurrently.
+func TestLockedBitsConcurrent(t *testing.T) {
+	var lb LockedBits
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			for j := 0; j < 1000; j++ {
+				lb.Bool()
+				lb.Uint(13)
+			}
+			wg.Done()
+		}()
+	}
+	wg.Wait()
+}
+
+// BenchmarkIntn2 benchmarks flipping a coin with Intn(2), as a baseline for
+// the Bool benchmarks.
+func BenchmarkIntn2(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Intn(2) == 1
+	}
+}
+
+// BenchmarkBool benchmarks the Bool function, which uses a shared cache.
+func BenchmarkBool(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Bool()
+	}
+}
+
+// BenchmarkBitsBool benchmarks the Bool method of a per-goroutine Bits.
+func BenchmarkBitsBool(b *testing.B) {
+	var bits Bits
+	for i := 0; i < b.N; i++ {
+		_ = bits.Bool()
+	}
+}
+
+// BenchmarkBitsUint7 benchmarks 7-bit draws from a per-goroutine Bits.
+func BenchmarkBitsUint7(b *testing.B) {
+	var bits Bits
+	for i := 0; i < b.N; i++ {
+		_ = bits.Uint(7)
+	}
+}
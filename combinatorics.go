@@ -0,0 +1,216 @@
+package fastrand
+
+import (
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// SubsetMask returns a uniform random k-subset of [0,n) as a bitmask, where
+// bit i is set if i is in the subset. It panics if n > 64, k < 0 or k > n.
+func SubsetMask(n, k int) uint64 {
+	if n > 64 {
+		panic("fastrand: argument to SubsetMask is > 64: " + strconv.Itoa(n))
+	}
+	var mask uint64
+	for _, i := range SampleInts(n, k) {
+		mask |= 1 << uint(i)
+	}
+	return mask
+}
+
+// Combination returns a uniform random k-combination of [0,n), in increasing
+// order. It draws a uniform rank in [0, n choose k) and returns the
+// combination with that rank in lexicographic order. It panics if k < 0 or
+// k > n.
+func Combination(n, k int) []int {
+	if k < 0 || k > n {
+		panic("fastrand: invalid combination size " + strconv.Itoa(k) + " for set of size " + strconv.Itoa(n))
+	}
+	total := new(big.Int).Binomial(int64(n), int64(k))
+	return unrankCombination(n, k, BigIntn(total))
+}
+
+// unrankCombination returns the k-combination of [0,n) with the given rank
+// in lexicographic order. rank is modified.
+func unrankCombination(n, k int, rank *big.Int) []int {
+	out := make([]int, 0, k)
+	count := new(big.Int)
+	for c := 0; len(out) < k; c++ {
+		// count is the number of combinations whose next element is c.
+		count.Binomial(int64(n-c-1), int64(k-len(out)-1))
+		if rank.Cmp(count) < 0 {
+			out = append(out, c)
+		} else {
+			rank.Sub(rank, count)
+		}
+	}
+	return out
+}
+
+// Composition returns a uniform random composition of n, that is, a sequence
+// of positive integers that sum to n. Each of the 2^(n-1) compositions is
+// equally likely. It panics if n < 0.
+func Composition(n int) []int {
+	if n < 0 {
+		panic("fastrand: argument to Composition is < 0: " + strconv.Itoa(n))
+	}
+	var parts []int
+	part := 0
+	for i := 1; i <= n; i++ {
+		part++
+		// Each of the n-1 gaps between units is a cut with probability 1/2.
+		if i == n || Bool() {
+			parts = append(parts, part)
+			part = 0
+		}
+	}
+	return parts
+}
+
+// CompositionK returns a uniform random composition of n into exactly k
+// positive parts. It panics if k < 1 or k > n.
+func CompositionK(n, k int) []int {
+	if k < 1 || k > n {
+		panic("fastrand: invalid number of parts " + strconv.Itoa(k) + " for composition of " + strconv.Itoa(n))
+	}
+	// Choose k-1 of the n-1 gaps between units as cuts.
+	parts := make([]int, 0, k)
+	prev := 0
+	for _, cut := range Combination(n-1, k-1) {
+		parts = append(parts, cut+1-prev)
+		prev = cut + 1
+	}
+	return append(parts, n-prev)
+}
+
+// partitionCounts returns p(0), ..., p(n), where p(m) is the number of
+// integer partitions of m, using Euler's pentagonal number recurrence.
+func partitionCounts(n int) []*big.Int {
+	p := make([]*big.Int, n+1)
+	p[0] = big.NewInt(1)
+	for m := 1; m <= n; m++ {
+		p[m] = new(big.Int)
+		for k := 1; ; k++ {
+			g1, g2 := k*(3*k-1)/2, k*(3*k+1)/2
+			if g1 > m {
+				break
+			}
+			sign := k%2 == 1
+			for _, g := range []int{g1, g2} {
+				if g > m {
+					continue
+				}
+				if sign {
+					p[m].Add(p[m], p[m-g])
+				} else {
+					p[m].Sub(p[m], p[m-g])
+				}
+			}
+		}
+	}
+	return p
+}
+
+// Partition returns a uniform random integer partition of n, that is, a
+// multiset of positive integers that sum to n, in non-increasing order. Each
+// partition is equally likely. It panics if n < 0.
+//
+// Partition uses the method of Nijenhuis and Wilf, "Combinatorial Algorithms"
+// (1978), which repeatedly removes j parts of size d from the remainder m
+// with probability d*p(m-jd) / (m*p(m)).
+func Partition(n int) []int {
+	if n < 0 {
+		panic("fastrand: argument to Partition is < 0: " + strconv.Itoa(n))
+	}
+	p := partitionCounts(n)
+	var parts []int
+	weight := new(big.Int)
+	for m := n; m > 0; {
+		total := new(big.Int).Mul(big.NewInt(int64(m)), p[m])
+		r := BigIntn(total)
+		d, j := 1, 1
+	search:
+		for d = 1; d <= m; d++ {
+			for j = 1; j*d <= m; j++ {
+				weight.Mul(big.NewInt(int64(d)), p[m-j*d])
+				if r.Cmp(weight) < 0 {
+					break search
+				}
+				r.Sub(r, weight)
+			}
+		}
+		for i := 0; i < j; i++ {
+			parts = append(parts, d)
+		}
+		m -= j * d
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(parts)))
+	return parts
+}
+
+// bellNumbers returns B(0), ..., B(n), where B(m) is the number of
+// partitions of a set of size m.
+func bellNumbers(n int) []*big.Int {
+	b := make([]*big.Int, n+1)
+	b[0] = big.NewInt(1)
+	binom := new(big.Int)
+	for m := 0; m < n; m++ {
+		// B(m+1) = sum over k of (m choose k) B(k).
+		b[m+1] = new(big.Int)
+		for k := 0; k <= m; k++ {
+			binom.Binomial(int64(m), int64(k))
+			b[m+1].Add(b[m+1], binom.Mul(binom, b[k]))
+		}
+	}
+	return b
+}
+
+// SetPartition returns a uniform random partition of the set [0,n) into
+// non-empty blocks. Each block is in increasing order, and the blocks are
+// ordered by their smallest element. Each of the B(n) partitions, where B is
+// the Bell numbers, is equally likely. It panics if n < 0.
+func SetPartition(n int) [][]int {
+	if n < 0 {
+		panic("fastrand: argument to SetPartition is < 0: " + strconv.Itoa(n))
+	}
+	bell := bellNumbers(n)
+	remaining := make([]int, n)
+	for i := range remaining {
+		remaining[i] = i
+	}
+	var blocks [][]int
+	weight := new(big.Int)
+	for len(remaining) > 0 {
+		// The block containing the smallest remaining element has size s with
+		// probability (m-1 choose s-1) B(m-s) / B(m).
+		m := len(remaining)
+		r := BigIntn(bell[m])
+		s := 1
+		for ; s < m; s++ {
+			weight.Binomial(int64(m-1), int64(s-1))
+			weight.Mul(weight, bell[m-s])
+			if r.Cmp(weight) < 0 {
+				break
+			}
+			r.Sub(r, weight)
+		}
+		// Choose the other members of the block uniformly.
+		block := []int{remaining[0]}
+		chosen := make(map[int]bool, s-1)
+		for _, i := range SampleInts(m-1, s-1) {
+			chosen[i+1] = true
+		}
+		rest := remaining[:0:0]
+		for i, x := range remaining[1:] {
+			if chosen[i+1] {
+				block = append(block, x)
+			} else {
+				rest = append(rest, x)
+			}
+		}
+		blocks = append(blocks, block)
+		remaining = rest
+	}
+	return blocks
+}
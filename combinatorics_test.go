@@ -0,0 +1,204 @@
+package fastrand
+
+import (
+	"fmt"
+	"math/big"
+	"math/bits"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+// checkUniform draws 200 samples per expected outcome from gen, and checks
+// that every outcome in valid is produced with equal probability, and that
+// no other outcome is produced. Outcomes are compared by their printed form.
+func checkUniform[T any](t *testing.T, valid []T, gen func() T) {
+	t.Helper()
+	index := make(map[string]int)
+	for _, v := range valid {
+		index[fmt.Sprint(v)] = len(index)
+	}
+	if len(index) != len(valid) {
+		t.Fatal("valid outcomes are not distinct")
+	}
+	checkChiSquare(t, 200*len(index), uniformProbs(len(index)), func() int {
+		v := gen()
+		i, ok := index[fmt.Sprint(v)]
+		if !ok {
+			t.Fatalf("generated invalid outcome %v", v)
+		}
+		return i
+	})
+}
+
+// TestSubsetMask tests that SubsetMask produces every k-subset with equal
+// probability.
+func TestSubsetMask(t *testing.T) {
+	if !panics(func() { SubsetMask(65, 1) }) {
+		t.Error("expected panic for n > 64")
+	}
+	var valid []uint64
+	for mask := uint64(0); mask < 1<<6; mask++ {
+		if bits.OnesCount64(mask) == 3 {
+			valid = append(valid, mask)
+		}
+	}
+	checkUniform(t, valid, func() uint64 { return SubsetMask(6, 3) })
+	if m := SubsetMask(64, 64); m != 1<<64-1 {
+		t.Errorf("expected full mask, got %x", m)
+	}
+}
+
+// TestCombination tests that Combination produces every combination with
+// equal probability, and that unranking enumerates them in lexicographic
+// order.
+func TestCombination(t *testing.T) {
+	if !panics(func() { Combination(3, 4) }) {
+		t.Error("expected panic for k > n")
+	}
+	var valid [][]int
+	for mask := 0; mask < 1<<6; mask++ {
+		if bits.OnesCount(uint(mask)) == 3 {
+			var c []int
+			for i := 0; i < 6; i++ {
+				if mask&(1<<uint(i)) != 0 {
+					c = append(c, i)
+				}
+			}
+			valid = append(valid, c)
+		}
+	}
+	sort.Slice(valid, func(i, j int) bool {
+		for k := range valid[i] {
+			if valid[i][k] != valid[j][k] {
+				return valid[i][k] < valid[j][k]
+			}
+		}
+		return false
+	})
+	for rank, c := range valid {
+		if u := unrankCombination(6, 3, big.NewInt(int64(rank))); !reflect.DeepEqual(u, c) {
+			t.Errorf("rank %v unranked to %v, expected %v", rank, u, c)
+		}
+	}
+	checkUniform(t, valid, func() []int { return Combination(6, 3) })
+	if c := Combination(100, 0); len(c) != 0 {
+		t.Errorf("expected empty combination, got %v", c)
+	}
+}
+
+// compositions returns every composition of n.
+func compositions(n int) [][]int {
+	if n == 0 {
+		return [][]int{nil}
+	}
+	var out [][]int
+	for first := 1; first <= n; first++ {
+		for _, rest := range compositions(n - first) {
+			out = append(out, append([]int{first}, rest...))
+		}
+	}
+	return out
+}
+
+// TestComposition tests that Composition and CompositionK produce every
+// composition with equal probability.
+func TestComposition(t *testing.T) {
+	if !panics(func() { Composition(-1) }) {
+		t.Error("expected panic for n < 0")
+	}
+	if !panics(func() { CompositionK(3, 4) }) {
+		t.Error("expected panic for k > n")
+	}
+	if !panics(func() { CompositionK(3, 0) }) {
+		t.Error("expected panic for k == 0")
+	}
+	checkUniform(t, compositions(6), func() []int { return Composition(6) })
+
+	var valid [][]int
+	for _, c := range compositions(7) {
+		if len(c) == 3 {
+			valid = append(valid, c)
+		}
+	}
+	checkUniform(t, valid, func() []int { return CompositionK(7, 3) })
+}
+
+// partitions returns every partition of n into parts no larger than max, in
+// non-increasing order.
+func partitions(n, max int) [][]int {
+	if n == 0 {
+		return [][]int{nil}
+	}
+	var out [][]int
+	if max > n {
+		max = n
+	}
+	for first := max; first >= 1; first-- {
+		for _, rest := range partitions(n-first, first) {
+			out = append(out, append([]int{first}, rest...))
+		}
+	}
+	return out
+}
+
+// TestPartition tests that Partition produces every integer partition with
+// equal probability.
+func TestPartition(t *testing.T) {
+	if !panics(func() { Partition(-1) }) {
+		t.Error("expected panic for n < 0")
+	}
+	p := partitionCounts(30)
+	for _, m := range []int{0, 1, 5, 8, 12} {
+		if got := p[m].Int64(); got != int64(len(partitions(m, m))) {
+			t.Errorf("p(%v) = %v, expected %v", m, got, len(partitions(m, m)))
+		}
+	}
+	if p[30].Int64() != 5604 {
+		t.Errorf("p(30) = %v, expected 5604", p[30])
+	}
+	checkUniform(t, partitions(8, 8), func() []int { return Partition(8) })
+	if s := Partition(0); len(s) != 0 {
+		t.Errorf("expected empty partition, got %v", s)
+	}
+}
+
+// setPartitions returns every partition of [0,n), in the form produced by
+// SetPartition.
+func setPartitions(n int) [][][]int {
+	if n == 0 {
+		return [][][]int{nil}
+	}
+	// Insert n-1 into each block of each partition of [0,n-1), or into a
+	// block of its own.
+	var out [][][]int
+	for _, p := range setPartitions(n - 1) {
+		for i := range p {
+			q := make([][]int, len(p))
+			for j := range p {
+				q[j] = append([]int(nil), p[j]...)
+			}
+			q[i] = append(q[i], n-1)
+			out = append(out, q)
+		}
+		out = append(out, append(append([][]int(nil), p...), []int{n - 1}))
+	}
+	return out
+}
+
+// TestSetPartition tests that SetPartition produces every set partition with
+// equal probability.
+func TestSetPartition(t *testing.T) {
+	if !panics(func() { SetPartition(-1) }) {
+		t.Error("expected panic for n < 0")
+	}
+	bell := bellNumbers(10)
+	if bell[5].Int64() != 52 || bell[10].Int64() != 115975 {
+		t.Errorf("unexpected Bell numbers %v", bell)
+	}
+	// There are B(5) = 52 partitions of a set of 5.
+	checkUniform(t, setPartitions(5), func() [][]int { return SetPartition(5) })
+	if s := SetPartition(0); len(s) != 0 {
+		t.Errorf("expected empty partition, got %v", s)
+	}
+}
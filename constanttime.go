@@ -0,0 +1,84 @@
+package fastrand
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// ConstantTimeUint64n returns a random uint64 in [0,n) using an amount of time
+// that does not depend on n. It panics if n == 0.
+//
+// Unlike Uint64n, which uses rejection sampling, ConstantTimeUint64n draws a
+// 128-bit value r and returns floor(r*n / 2^128). The result is not perfectly
+// uniform: the statistical distance from the uniform distribution on [0,n) is
+// less than n/2^128, which is at most 2^-64. This makes it suitable for
+// sampling with secret bounds, such as scalars modulo a secret modulus.
+func ConstantTimeUint64n(n uint64) uint64 {
+	if n == 0 {
+		panic("fastrand: argument to ConstantTimeUint64n is 0")
+	}
+	var b [16]byte
+	Read(b[:])
+	lo := binary.LittleEndian.Uint64(b[0:8])
+	hi := binary.LittleEndian.Uint64(b[8:16])
+
+	// Compute the top 64 bits of the 192-bit product (hi*2^64 + lo) * n.
+	hiHi, hiLo := bits.Mul64(hi, n)
+	loHi, _ := bits.Mul64(lo, n)
+	_, carry := bits.Add64(hiLo, loHi, 0)
+	return hiHi + carry
+}
+
+// ConstantTimeBigIntn returns a random *big.Int in [0,n) using an amount of
+// time that depends only on the bit length of n. It panics if n <= 0.
+//
+// ConstantTimeBigIntn draws a value r with 64 more bits than n and returns
+// floor(r*n / 2^k), where k is the bit length of r. The statistical distance
+// from the uniform distribution on [0,n) is less than 2^-64. Note that the
+// returned *big.Int, like any *big.Int, is not itself a constant-time
+// representation; only the sampling and reduction are.
+func ConstantTimeBigIntn(n *big.Int) *big.Int {
+	if n.Sign() <= 0 {
+		panic("fastrand: argument to ConstantTimeBigIntn is <= 0")
+	}
+	// Convert n to little-endian 64-bit words.
+	words := (n.BitLen() + 63) / 64
+	nBytes := n.FillBytes(make([]byte, words*8))
+	nWords := make([]uint64, words)
+	for i := range nWords {
+		nWords[i] = binary.BigEndian.Uint64(nBytes[len(nBytes)-8*(i+1):])
+	}
+
+	// Draw r with one more word than n.
+	rBytes := Bytes((words + 1) * 8)
+	rWords := make([]uint64, words+1)
+	for i := range rWords {
+		rWords[i] = binary.LittleEndian.Uint64(rBytes[8*i:])
+	}
+
+	// Compute the full product r*n using schoolbook multiplication. The loop
+	// bounds depend only on the number of words, not on their values.
+	prod := make([]uint64, len(rWords)+len(nWords))
+	for i, ri := range rWords {
+		var carry uint64
+		for j, nj := range nWords {
+			hi, lo := bits.Mul64(ri, nj)
+			var c uint64
+			lo, c = bits.Add64(lo, prod[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			prod[i+j] = lo
+			carry = hi
+		}
+		prod[i+len(nWords)] = carry
+	}
+
+	// The result is the top len(nWords) words of the product.
+	out := make([]byte, words*8)
+	for i, w := range prod[len(rWords):] {
+		binary.BigEndian.PutUint64(out[len(out)-8*(i+1):], w)
+	}
+	return new(big.Int).SetBytes(out)
+}
@@ -0,0 +1,171 @@
+package fastrand
+
+import (
+	"math"
+	"math/big"
+	"os"
+	"sort"
+	"testing"
+	"time"
+)
+
+// timingLeak measures fn over two classes of inputs, dudect-style, and
+// returns Welch's t-statistic for the difference in their execution times.
+// For each measurement, a class is chosen at random and fn is called with it
+// batch times. The slowest measurements are cropped before computing the
+// statistic, as they are dominated by scheduling noise.
+func timingLeak(samples, batch int, fn func(class int)) float64 {
+	classes := make([]int, samples)
+	times := make([]float64, samples)
+	for i := range classes {
+		classes[i] = Intn(2)
+	}
+	for i, c := range classes {
+		start := time.Now()
+		for j := 0; j < batch; j++ {
+			fn(c)
+		}
+		times[i] = float64(time.Since(start))
+	}
+
+	// Crop everything above the 90th percentile.
+	sorted := append([]float64(nil), times...)
+	sort.Float64s(sorted)
+	cutoff := sorted[len(sorted)*9/10]
+
+	var n, mean, m2 [2]float64
+	for i, t := range times {
+		if t > cutoff {
+			continue
+		}
+		c := classes[i]
+		n[c]++
+		delta := t - mean[c]
+		mean[c] += delta / n[c]
+		m2[c] += delta * (t - mean[c])
+	}
+	v0, v1 := m2[0]/(n[0]-1), m2[1]/(n[1]-1)
+	return (mean[0] - mean[1]) / math.Sqrt(v0/n[0]+v1/n[1])
+}
+
+// TestConstantTimeUint64nPanics tests that ConstantTimeUint64n panics if n == 0.
+func TestConstantTimeUint64nPanics(t *testing.T) {
+	if !panics(func() { ConstantTimeUint64n(0) }) {
+		t.Error("expected panic for n == 0")
+	}
+	if panics(func() { ConstantTimeUint64n(math.MaxUint64) }) {
+		t.Error("did not expect panic for n > 0")
+	}
+}
+
+// TestConstantTimeBigIntnPanics tests that ConstantTimeBigIntn panics if n <= 0.
+func TestConstantTimeBigIntnPanics(t *testing.T) {
+	if !panics(func() { ConstantTimeBigIntn(big.NewInt(-1)) }) {
+		t.Error("expected panic for n < 0")
+	}
+	if !panics(func() { ConstantTimeBigIntn(big.NewInt(0)) }) {
+		t.Error("expected panic for n == 0")
+	}
+	if panics(func() { ConstantTimeBigIntn(big.NewInt(1)) }) {
+		t.Error("did not expect panic for n > 0")
+	}
+}
+
+// TestConstantTimeUint64n tests the ConstantTimeUint64n function.
+func TestConstantTimeUint64n(t *testing.T) {
+	const iters = 40000
+	var counts [10]uint64
+	for i := 0; i < iters; i++ {
+		counts[ConstantTimeUint64n(uint64(len(counts)))]++
+	}
+	exp := iters / uint64(len(counts))
+	lower, upper := exp-(exp/10), exp+(exp/10)
+	for i, n := range counts {
+		if !(lower < n && n < upper) {
+			t.Errorf("Expected range of %v-%v for index %v, got %v", lower, upper, i, n)
+		}
+	}
+
+	// Large bounds should never be exceeded.
+	for _, n := range []uint64{1, 2, math.MaxUint64 / 2, math.MaxUint64/2 + 1, math.MaxUint64} {
+		for i := 0; i < 1000; i++ {
+			if r := ConstantTimeUint64n(n); r >= n {
+				t.Fatalf("ConstantTimeUint64n(%v) returned %v", n, r)
+			}
+		}
+	}
+}
+
+// TestConstantTimeBigIntn tests the ConstantTimeBigIntn function.
+func TestConstantTimeBigIntn(t *testing.T) {
+	const iters = 40000
+	var counts [10]int
+	for i := 0; i < iters; i++ {
+		counts[ConstantTimeBigIntn(big.NewInt(int64(len(counts)))).Int64()]++
+	}
+	exp := iters / len(counts)
+	lower, upper := exp-(exp/10), exp+(exp/10)
+	for i, n := range counts {
+		if !(lower < n && n < upper) {
+			t.Errorf("Expected range of %v-%v for index %v, got %v", lower, upper, i, n)
+		}
+	}
+
+	// Multi-word bounds should never be exceeded, and the top half of the
+	// range should be hit about half the time.
+	huge := new(big.Int).Exp(big.NewInt(math.MaxInt64), big.NewInt(10), nil)
+	half := new(big.Int).Rsh(huge, 1)
+	var above int
+	for i := 0; i < iters; i++ {
+		r := ConstantTimeBigIntn(huge)
+		if r.Sign() < 0 || r.Cmp(huge) >= 0 {
+			t.Fatalf("ConstantTimeBigIntn(%v) returned %v", huge, r)
+		}
+		if r.Cmp(half) >= 0 {
+			above++
+		}
+	}
+	if above < iters*45/100 || above > iters*55/100 {
+		t.Errorf("expected roughly %v values in the upper half, got %v", iters/2, above)
+	}
+}
+
+// TestConstantTimeUint64nTiming checks that the running time of
+// ConstantTimeUint64n does not depend on its argument, and that the same
+// harness detects the data-dependent running time of Uint64n.
+func TestConstantTimeUint64nTiming(t *testing.T) {
+	// Wall-clock measurements are unreliable on loaded machines, so timing
+	// tests only run when requested.
+	if os.Getenv("FASTRAND_TIMING") == "" {
+		t.Skip("set FASTRAND_TIMING=1 to run timing tests")
+	}
+	// These bounds are the best and worst cases for Uint64n's rejection loop.
+	bounds := [2]uint64{1, math.MaxUint64/2 + 1}
+	const samples, batch = 20000, 16
+
+	tc := timingLeak(samples, batch, func(c int) { ConstantTimeUint64n(bounds[c]) })
+	if math.Abs(tc) > 10 {
+		t.Errorf("ConstantTimeUint64n timing depends on its argument: t = %.2f", tc)
+	}
+	tv := timingLeak(samples, batch, func(c int) { Uint64n(bounds[c]) })
+	if math.Abs(tv) < 10 {
+		t.Errorf("harness failed to detect Uint64n timing leak: t = %.2f", tv)
+	}
+}
+
+// BenchmarkConstantTimeUint64n benchmarks the ConstantTimeUint64n function.
+func BenchmarkConstantTimeUint64n(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = ConstantTimeUint64n(math.MaxUint64/2 + 1)
+	}
+}
+
+// BenchmarkConstantTimeBigIntnLarge benchmarks the ConstantTimeBigIntn
+// function for large ints.
+func BenchmarkConstantTimeBigIntnLarge(b *testing.B) {
+	// (2^63)^10
+	huge := new(big.Int).Exp(big.NewInt(math.MaxInt64), big.NewInt(10), nil)
+	for i := 0; i < b.N; i++ {
+		_ = ConstantTimeBigIntn(huge)
+	}
+}
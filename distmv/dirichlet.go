@@ -0,0 +1,74 @@
+package distmv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+	"github.com/NebulousLabs/fastrand/distuv"
+)
+
+// Dirichlet is the Dirichlet distribution with concentration parameters
+// Alpha. Its values are probability vectors: non-negative, with sum 1.
+type Dirichlet struct {
+	Alpha []float64
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random vector drawn from the distribution, by normalizing
+// independent Gamma(Alpha[i], 1) values. It panics if Alpha is empty or has
+// a non-positive entry.
+func (d Dirichlet) Rand() []float64 {
+	d.check()
+	x := make([]float64, len(d.Alpha))
+	for {
+		var sum float64
+		for i, a := range d.Alpha {
+			x[i] = distuv.Gamma{Alpha: a, Beta: 1, Src: d.Src}.Rand()
+			sum += x[i]
+		}
+		// With tiny concentrations, every Gamma value can underflow to zero.
+		if sum > 0 {
+			for i := range x {
+				x[i] /= sum
+			}
+			return x
+		}
+	}
+}
+
+// PDF returns the probability density at x, with respect to the Lebesgue
+// measure on the first len(x)-1 coordinates. It is zero unless x is a
+// probability vector.
+func (d Dirichlet) PDF(x []float64) float64 {
+	d.check()
+	if len(x) != len(d.Alpha) {
+		panic("fastrand: Dirichlet dimension mismatch")
+	}
+	var sum, alpha0, logp float64
+	for i, a := range d.Alpha {
+		if x[i] < 0 {
+			return 0
+		}
+		sum += x[i]
+		alpha0 += a
+		lg, _ := math.Lgamma(a)
+		logp += (a-1)*math.Log(x[i]) - lg
+	}
+	if math.Abs(sum-1) > 1e-12 {
+		return 0
+	}
+	lg0, _ := math.Lgamma(alpha0)
+	return math.Exp(logp + lg0)
+}
+
+// check panics if Alpha is invalid.
+func (d Dirichlet) check() {
+	if len(d.Alpha) == 0 {
+		panic("fastrand: Dirichlet has no parameters")
+	}
+	for _, a := range d.Alpha {
+		if !(a > 0) || math.IsInf(a, 0) {
+			panic("fastrand: Dirichlet parameter is not positive")
+		}
+	}
+}
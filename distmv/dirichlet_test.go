@@ -0,0 +1,87 @@
+package distmv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand/distuv"
+)
+
+// TestDirichlet tests that Dirichlet's values are probability vectors whose
+// coordinates have the expected Beta marginals.
+func TestDirichlet(t *testing.T) {
+	const n = 10000
+	for _, alpha := range [][]float64{
+		{1, 1},
+		{0.5, 2, 3},
+		{0.1, 0.1, 0.1},
+		{20, 1, 1, 5},
+	} {
+		d := Dirichlet{Alpha: alpha}
+		var alpha0 float64
+		for _, a := range alpha {
+			alpha0 += a
+		}
+		xs := make([]float64, n)
+		for i := range xs {
+			v := d.Rand()
+			var sum float64
+			for _, x := range v {
+				if x < 0 {
+					t.Fatalf("Dirichlet returned %v", v)
+				}
+				sum += x
+			}
+			if math.Abs(sum-1) > 1e-12 {
+				t.Fatalf("Dirichlet returned %v", v)
+			}
+			xs[i] = v[i%len(v)]
+		}
+		// The coordinates are checked in turn, so compare against the
+		// mixture of their marginals.
+		checkKS(t, "Dirichlet", xs, func(x float64) float64 {
+			var c float64
+			for _, a := range alpha {
+				c += distuv.Beta{Alpha: a, Beta: alpha0 - a}.CDF(x)
+			}
+			return c / float64(len(alpha))
+		})
+	}
+
+	// With tiny concentrations, all of the Gamma values often underflow, and
+	// must be redrawn rather than normalized to NaN.
+	d := Dirichlet{Alpha: []float64{1e-3, 1e-3}}
+	for i := 0; i < 1000; i++ {
+		if v := d.Rand(); !(math.Abs(v[0]+v[1]-1) < 1e-12) {
+			t.Fatalf("Dirichlet returned %v", v)
+		}
+	}
+}
+
+// TestDirichletPDF tests that the Dirichlet PDF reduces to the Beta PDF in
+// two dimensions, and is constant for unit concentrations.
+func TestDirichletPDF(t *testing.T) {
+	d := Dirichlet{Alpha: []float64{2, 3.5}}
+	b := distuv.Beta{Alpha: 2, Beta: 3.5}
+	for _, x := range []float64{0.1, 0.5, 0.75} {
+		if p, q := d.PDF([]float64{x, 1 - x}), b.PDF(x); math.Abs(p-q) > 1e-12*q {
+			t.Errorf("Dirichlet PDF at %v is %v, expected %v", x, p, q)
+		}
+	}
+	flat := Dirichlet{Alpha: []float64{1, 1, 1}}
+	if p := flat.PDF([]float64{0.2, 0.3, 0.5}); math.Abs(p-2) > 1e-12 {
+		t.Errorf("expected density 2, got %v", p)
+	}
+	if p := flat.PDF([]float64{0.2, 0.3, 0.6}); p != 0 {
+		t.Errorf("expected density 0 off the simplex, got %v", p)
+	}
+}
+
+// TestDirichletPanics tests that Dirichlet panics on invalid parameters.
+func TestDirichletPanics(t *testing.T) {
+	for _, alpha := range [][]float64{nil, {1, 0}, {1, -1}, {1, math.NaN()}, {1, math.Inf(1)}} {
+		if !panics(func() { Dirichlet{Alpha: alpha}.Rand() }) {
+			t.Errorf("expected panic for %v", alpha)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+// Package distmv provides multivariate probability distributions and
+// uniform distributions over geometric shapes. Each distribution draws random
+// points with Rand, and points are represented as []float64.
+//
+// As in distuv, every distribution has a Src field, which is the
+// fastrand.Generator used by Rand. A nil Src draws from fastrand.Reader.
+package distmv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+	"github.com/NebulousLabs/fastrand/distuv"
+)
+
+// normalVector returns a vector of dim independent standard normal values.
+func normalVector(dim int, src *fastrand.Generator) []float64 {
+	n := distuv.Normal{Mu: 0, Sigma: 1, Src: src}
+	v := make([]float64, dim)
+	for i := range v {
+		v[i] = n.Rand()
+	}
+	return v
+}
+
+// norm returns the Euclidean norm of v.
+func norm(v []float64) float64 {
+	var sum float64
+	for _, x := range v {
+		sum += x * x
+	}
+	return math.Sqrt(sum)
+}
+
+// checkDim panics if dim is not positive.
+func checkDim(dim int) {
+	if dim < 1 {
+		panic("fastrand: dimension is not positive")
+	}
+}
@@ -0,0 +1,99 @@
+package distmv
+
+import (
+	"bytes"
+	"math"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// ksCritical is the coefficient of the Kolmogorov-Smirnov critical value at
+// a significance level of about 1e-4.
+const ksCritical = 2.23
+
+// checkKS fails the test if the Kolmogorov-Smirnov statistic of xs against
+// cdf exceeds the critical value. It sorts xs.
+func checkKS(t *testing.T, name string, xs []float64, cdf func(float64) float64) {
+	t.Helper()
+	sort.Float64s(xs)
+	n := float64(len(xs))
+	var stat float64
+	for i, x := range xs {
+		c := cdf(x)
+		stat = math.Max(stat, math.Max(float64(i+1)/n-c, c-float64(i)/n))
+	}
+	if crit := ksCritical / math.Sqrt(n); stat > crit {
+		t.Errorf("%v: Kolmogorov-Smirnov statistic %.4f exceeds critical value %.4f", name, stat, crit)
+	}
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkUniformCounts fails the test if counts are not consistent with a
+// uniform distribution over their categories.
+func checkUniformCounts(t *testing.T, name string, counts []int) {
+	t.Helper()
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	e := float64(total) / float64(len(counts))
+	var stat float64
+	for _, c := range counts {
+		stat += (float64(c) - e) * (float64(c) - e) / e
+	}
+	if crit := chiSquareCritical(len(counts) - 1); stat > crit {
+		t.Errorf("%v: chi-square statistic %.2f exceeds critical value %.2f; counts %v", name, stat, crit, counts)
+	}
+}
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// TestSrc tests that each distribution draws only from its Src.
+func TestSrc(t *testing.T) {
+	seed := fastrand.Bytes(1 << 16)
+	makeDists := func(seed []byte) []func() any {
+		src := fastrand.NewGenerator(bytes.NewReader(seed))
+		square := [][2]float64{{0, 0}, {1, 0}, {1, 1}, {0, 1}}
+		polygon := NewPolygon(square)
+		polygon.Src = src
+		normal := NewNormal([]float64{0, 0}, [][]float64{{1, 0.5}, {0.5, 1}})
+		normal.Src = src
+		return []func() any{
+			func() any { return Ball{Dim: 3, Src: src}.Rand() },
+			func() any { return Simplex{Vertices: [][]float64{{0}, {1}}, Src: src}.Rand() },
+			func() any { return polygon.Rand() },
+			func() any { return Rotation{Dim: 3, Src: src}.Rand() },
+			func() any { return Dirichlet{Alpha: []float64{1, 2}, Src: src}.Rand() },
+			func() any { return normal.Rand() },
+		}
+	}
+	a, b, c := makeDists(seed), makeDists(seed), makeDists(fastrand.Bytes(1<<16))
+	for i := range a {
+		x, y, z := a[i](), b[i](), c[i]()
+		if !reflect.DeepEqual(x, y) {
+			t.Errorf("distribution %v: same source produced %v and %v", i, x, y)
+		}
+		if reflect.DeepEqual(x, z) {
+			t.Errorf("distribution %v: different sources both produced %v", i, x)
+		}
+	}
+}
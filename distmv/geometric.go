@@ -0,0 +1,215 @@
+package distmv
+
+import (
+	"math"
+	"sort"
+
+	"github.com/NebulousLabs/fastrand"
+	"github.com/NebulousLabs/fastrand/distuv"
+)
+
+// Sphere is the uniform distribution on the unit sphere in Dim dimensions,
+// that is, on the set of points with norm 1. Points on other spheres can be
+// obtained by scaling and translating.
+type Sphere struct {
+	Dim int
+	Src *fastrand.Generator
+}
+
+// Rand returns a random point on the sphere, by normalizing a vector of
+// independent normal values, whose distribution is rotationally symmetric.
+// It panics if Dim < 1.
+func (s Sphere) Rand() []float64 {
+	checkDim(s.Dim)
+	for {
+		v := normalVector(s.Dim, s.Src)
+		if r := norm(v); r > 0 {
+			for i := range v {
+				v[i] /= r
+			}
+			return v
+		}
+	}
+}
+
+// Ball is the uniform distribution on the unit ball in Dim dimensions, that
+// is, on the set of points with norm at most 1.
+type Ball struct {
+	Dim int
+	Src *fastrand.Generator
+}
+
+// Rand returns a random point in the ball. A random point on the sphere is
+// scaled by U^(1/Dim) for a uniform U, since the volume within radius r is
+// proportional to r^Dim. It panics if Dim < 1.
+func (b Ball) Rand() []float64 {
+	v := Sphere{Dim: b.Dim, Src: b.Src}.Rand()
+	r := math.Pow(b.Src.Float64(), 1/float64(b.Dim))
+	for i := range v {
+		v[i] *= r
+	}
+	return v
+}
+
+// Simplex is the uniform distribution on the simplex with the given
+// vertices: the set of their convex combinations. A triangle is a simplex
+// with three vertices. The vertices must all have the same dimension, which
+// is the dimension of the points returned.
+type Simplex struct {
+	Vertices [][]float64
+	Src      *fastrand.Generator
+}
+
+// Rand returns a random point in the simplex. Normalizing independent
+// exponential values gives uniform barycentric coordinates, which map
+// uniform points of the standard simplex to uniform points of any other. It
+// panics if there are no vertices, or if their dimensions differ.
+func (s Simplex) Rand() []float64 {
+	if len(s.Vertices) == 0 {
+		panic("fastrand: Simplex has no vertices")
+	}
+	dim := len(s.Vertices[0])
+	e := distuv.Exponential{Rate: 1, Src: s.Src}
+	weights := make([]float64, len(s.Vertices))
+	var sum float64
+	for i := range weights {
+		weights[i] = e.Rand()
+		sum += weights[i]
+	}
+	p := make([]float64, dim)
+	for i, v := range s.Vertices {
+		if len(v) != dim {
+			panic("fastrand: Simplex vertices have different dimensions")
+		}
+		for j, x := range v {
+			p[j] += weights[i] / sum * x
+		}
+	}
+	return p
+}
+
+// A Polygon is the uniform distribution on a simple polygon in the plane,
+// which may be concave. The polygon is split into triangles when it is
+// created, so that each draw chooses a triangle in proportion to its area and
+// then a point within it.
+type Polygon struct {
+	triangles [][3][2]float64
+	cum       []float64 // cum[i] is the total area of triangles[:i+1]
+	Src       *fastrand.Generator
+}
+
+// NewPolygon returns a Polygon with the given vertices, in either clockwise
+// or counterclockwise order, and a nil Src. The edges must not cross.
+// It panics if there are fewer than three vertices, or if the polygon has no
+// area.
+func NewPolygon(vertices [][2]float64) *Polygon {
+	if len(vertices) < 3 {
+		panic("fastrand: Polygon has fewer than three vertices")
+	}
+	p := new(Polygon)
+	var total float64
+	for _, t := range triangulate(vertices) {
+		total += triangleArea(t)
+		p.triangles = append(p.triangles, t)
+		p.cum = append(p.cum, total)
+	}
+	if !(total > 0) {
+		panic("fastrand: Polygon has no area")
+	}
+	return p
+}
+
+// Rand returns a random point in the polygon.
+func (p *Polygon) Rand() [2]float64 {
+	total := p.cum[len(p.cum)-1]
+	u := p.Src.Float64() * total
+	i := sort.Search(len(p.cum), func(i int) bool { return p.cum[i] > u })
+	// Rounding can leave u at the total; take the last triangle with a
+	// positive area instead.
+	for i == len(p.cum) || (i > 0 && p.cum[i] == p.cum[i-1]) {
+		i--
+	}
+	t := p.triangles[i]
+	x := Simplex{Vertices: [][]float64{t[0][:], t[1][:], t[2][:]}, Src: p.Src}.Rand()
+	return [2]float64{x[0], x[1]}
+}
+
+// Area returns the area of the polygon.
+func (p *Polygon) Area() float64 {
+	return p.cum[len(p.cum)-1]
+}
+
+// cross returns the z component of the cross product of b-a and c-a, which
+// is positive if a, b, c turn counterclockwise.
+func cross(a, b, c [2]float64) float64 {
+	return (b[0]-a[0])*(c[1]-a[1]) - (b[1]-a[1])*(c[0]-a[0])
+}
+
+// triangleArea returns the area of t.
+func triangleArea(t [3][2]float64) float64 {
+	return math.Abs(cross(t[0], t[1], t[2])) / 2
+}
+
+// triangulate splits a simple polygon into triangles by ear clipping. An ear
+// is a convex vertex whose triangle with its neighbours contains no other
+// vertex; every simple polygon with more than three vertices has one.
+func triangulate(vertices [][2]float64) [][3][2]float64 {
+	// Work counterclockwise, so that convex vertices turn left.
+	var area float64
+	for i, v := range vertices {
+		w := vertices[(i+1)%len(vertices)]
+		area += v[0]*w[1] - w[0]*v[1]
+	}
+	poly := append([][2]float64(nil), vertices...)
+	if area < 0 {
+		for i, j := 0, len(poly)-1; i < j; i, j = i+1, j-1 {
+			poly[i], poly[j] = poly[j], poly[i]
+		}
+	}
+
+	var tris [][3][2]float64
+	for len(poly) > 3 {
+		i := findEar(poly)
+		n := len(poly)
+		prev, next := poly[(i+n-1)%n], poly[(i+1)%n]
+		tris = append(tris, [3][2]float64{prev, poly[i], next})
+		poly = append(poly[:i], poly[i+1:]...)
+	}
+	return append(tris, [3][2]float64{poly[0], poly[1], poly[2]})
+}
+
+// findEar returns the index of an ear of the counterclockwise polygon poly.
+// If rounding or degenerate input leaves no strictly convex ear, it returns a
+// vertex with no turn, or failing that any vertex, so that triangulation
+// always terminates.
+func findEar(poly [][2]float64) int {
+	n := len(poly)
+	flat := -1
+	for i := range poly {
+		a, b, c := poly[(i+n-1)%n], poly[i], poly[(i+1)%n]
+		turn := cross(a, b, c)
+		if turn == 0 && flat < 0 {
+			flat = i
+		}
+		if turn <= 0 {
+			continue
+		}
+		ear := true
+		for j, p := range poly {
+			if j == (i+n-1)%n || j == i || j == (i+1)%n {
+				continue
+			}
+			if cross(a, b, p) >= 0 && cross(b, c, p) >= 0 && cross(c, a, p) >= 0 {
+				ear = false
+				break
+			}
+		}
+		if ear {
+			return i
+		}
+	}
+	if flat < 0 {
+		return 0
+	}
+	return flat
+}
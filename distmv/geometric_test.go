@@ -0,0 +1,210 @@
+package distmv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSphere tests that Sphere's points have norm 1, and that their
+// coordinates have the distributions implied by rotational symmetry.
+func TestSphere(t *testing.T) {
+	const n = 10000
+	for _, dim := range []int{1, 2, 3, 10} {
+		s := Sphere{Dim: dim}
+		var angles, heights []float64
+		var positive int
+		for i := 0; i < n; i++ {
+			v := s.Rand()
+			if len(v) != dim || math.Abs(norm(v)-1) > 1e-12 {
+				t.Fatalf("Sphere(%v) returned %v", dim, v)
+			}
+			switch dim {
+			case 1:
+				if v[0] > 0 {
+					positive++
+				}
+			case 2:
+				angles = append(angles, math.Atan2(v[1], v[0]))
+			case 3:
+				// By Archimedes' hat-box theorem, each coordinate is
+				// uniform on [-1,1].
+				heights = append(heights, v[2])
+			}
+		}
+		switch dim {
+		case 1:
+			checkUniformCounts(t, "Sphere(1)", []int{positive, n - positive})
+		case 2:
+			checkKS(t, "Sphere(2)", angles, func(x float64) float64 { return (x + math.Pi) / (2 * math.Pi) })
+		case 3:
+			checkKS(t, "Sphere(3)", heights, func(x float64) float64 { return (x + 1) / 2 })
+		}
+	}
+}
+
+// TestBall tests that Ball's points have the radial distribution of the
+// uniform distribution on the ball.
+func TestBall(t *testing.T) {
+	const n = 10000
+	for _, dim := range []int{1, 2, 5} {
+		b := Ball{Dim: dim}
+		radii := make([]float64, n)
+		for i := range radii {
+			v := b.Rand()
+			if len(v) != dim || norm(v) > 1 {
+				t.Fatalf("Ball(%v) returned %v", dim, v)
+			}
+			radii[i] = norm(v)
+		}
+		checkKS(t, "Ball", radii, func(r float64) float64 { return math.Pow(r, float64(dim)) })
+	}
+}
+
+// TestSimplex tests Simplex on the standard simplices, where a point's
+// coordinates are its barycentric coordinates, and on a general triangle.
+func TestSimplex(t *testing.T) {
+	const n = 10000
+	for _, k := range []int{2, 3, 4} {
+		vertices := make([][]float64, k)
+		for i := range vertices {
+			vertices[i] = make([]float64, k)
+			vertices[i][i] = 1
+		}
+		s := Simplex{Vertices: vertices}
+		// Each barycentric coordinate has distribution Beta(1, k-1).
+		xs := make([]float64, n)
+		for i := range xs {
+			v := s.Rand()
+			var sum float64
+			for _, x := range v {
+				if x < 0 {
+					t.Fatalf("Simplex returned %v", v)
+				}
+				sum += x
+			}
+			if math.Abs(sum-1) > 1e-12 {
+				t.Fatalf("Simplex returned %v", v)
+			}
+			xs[i] = v[0]
+		}
+		checkKS(t, "Simplex", xs, func(x float64) float64 { return 1 - math.Pow(1-x, float64(k-1)) })
+	}
+
+	// The midpoints of a triangle's edges split it into four triangles of
+	// equal area.
+	a, b, c := [2]float64{-1, 0}, [2]float64{3, 1}, [2]float64{0, 2}
+	s := Simplex{Vertices: [][]float64{a[:], b[:], c[:]}}
+	mid := func(p, q [2]float64) [2]float64 { return [2]float64{(p[0] + q[0]) / 2, (p[1] + q[1]) / 2} }
+	ab, bc, ca := mid(a, b), mid(b, c), mid(c, a)
+	parts := [][3][2]float64{{a, ab, ca}, {ab, b, bc}, {ca, bc, c}, {ab, bc, ca}}
+	counts := make([]int, len(parts))
+	for i := 0; i < n; i++ {
+		v := s.Rand()
+		p := [2]float64{v[0], v[1]}
+		j := 0
+		for j < len(parts) && !inTriangle(p, parts[j]) {
+			j++
+		}
+		if j == len(parts) {
+			t.Fatalf("Simplex returned %v, outside the triangle", v)
+		}
+		counts[j]++
+	}
+	checkUniformCounts(t, "Simplex", counts)
+}
+
+// inTriangle reports whether p lies in the counterclockwise triangle t,
+// allowing for rounding error.
+func inTriangle(p [2]float64, t [3][2]float64) bool {
+	const eps = 1e-12
+	return cross(t[0], t[1], p) >= -eps && cross(t[1], t[2], p) >= -eps && cross(t[2], t[0], p) >= -eps
+}
+
+// TestPolygon tests Polygon on a concave L-shaped polygon, given in both
+// orientations, by counting points in each of its three unit squares.
+func TestPolygon(t *testing.T) {
+	l := [][2]float64{{0, 0}, {2, 0}, {2, 1}, {1, 1}, {1, 2}, {0, 2}}
+	reversed := make([][2]float64, len(l))
+	for i, v := range l {
+		reversed[len(l)-1-i] = v
+	}
+	for _, vertices := range [][][2]float64{l, reversed} {
+		p := NewPolygon(vertices)
+		if a := p.Area(); math.Abs(a-3) > 1e-12 {
+			t.Errorf("expected area 3, got %v", a)
+		}
+		counts := make([]int, 3)
+		for i := 0; i < 30000; i++ {
+			x := p.Rand()
+			switch {
+			case x[0] < 0 || x[1] < 0 || x[0] > 2 || x[1] > 2 || (x[0] > 1 && x[1] > 1):
+				t.Fatalf("Polygon returned %v, outside the polygon", x)
+			case x[0] > 1:
+				counts[1]++
+			case x[1] > 1:
+				counts[2]++
+			default:
+				counts[0]++
+			}
+		}
+		checkUniformCounts(t, "Polygon", counts)
+	}
+}
+
+// TestTriangulate tests that triangulate covers polygons with their exact
+// area, including a star and a polygon with collinear vertices.
+func TestTriangulate(t *testing.T) {
+	star := make([][2]float64, 10)
+	for i := range star {
+		r := 1.0
+		if i%2 == 1 {
+			r = 0.4
+		}
+		theta := 2 * math.Pi * float64(i) / 10
+		star[i] = [2]float64{r * math.Cos(theta), r * math.Sin(theta)}
+	}
+	for _, poly := range [][][2]float64{
+		{{0, 0}, {1, 0}, {0, 1}},
+		{{0, 0}, {1, 0}, {2, 0}, {2, 2}, {0, 2}},
+		{{0, 0}, {4, 0}, {4, 4}, {2, 1}, {0, 4}},
+		star,
+	} {
+		var shoelace float64
+		for i, v := range poly {
+			w := poly[(i+1)%len(poly)]
+			shoelace += v[0]*w[1] - w[0]*v[1]
+		}
+		shoelace = math.Abs(shoelace) / 2
+		tris := triangulate(poly)
+		if len(tris) != len(poly)-2 {
+			t.Errorf("expected %v triangles, got %v", len(poly)-2, len(tris))
+		}
+		var area float64
+		for _, tri := range tris {
+			if cross(tri[0], tri[1], tri[2]) < 0 {
+				t.Errorf("triangle %v is clockwise", tri)
+			}
+			area += triangleArea(tri)
+		}
+		if math.Abs(area-shoelace) > 1e-12 {
+			t.Errorf("triangles cover area %v, expected %v", area, shoelace)
+		}
+	}
+}
+
+// TestGeometricPanics tests that the geometric distributions panic on
+// invalid parameters.
+func TestGeometricPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"Sphere(0)":          func() { Sphere{}.Rand() },
+		"Ball(0)":            func() { Ball{}.Rand() },
+		"empty Simplex":      func() { Simplex{}.Rand() },
+		"ragged Simplex":     func() { Simplex{Vertices: [][]float64{{0, 0}, {1}}}.Rand() },
+		"two-vertex Polygon": func() { NewPolygon([][2]float64{{0, 0}, {1, 1}}) },
+		"flat Polygon":       func() { NewPolygon([][2]float64{{0, 0}, {1, 1}, {2, 2}}) },
+	} {
+		if !panics(fn) {
+			t.Errorf("expected panic for %v", name)
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package distmv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// A Normal is the multivariate normal distribution with a given mean and
+// covariance matrix.
+type Normal struct {
+	mu     []float64
+	chol   [][]float64 // lower triangular, with chol * chol^T = sigma
+	logDet float64     // log of the determinant of sigma
+	Src    *fastrand.Generator
+}
+
+// NewNormal returns a Normal with mean mu and covariance sigma, indexed by
+// row and then column, and a nil Src. The matrix is factored once, so
+// each draw costs O(n^2). It panics if sigma is not a symmetric positive
+// definite matrix of the same dimension as mu.
+func NewNormal(mu []float64, sigma [][]float64) *Normal {
+	n := len(mu)
+	checkDim(n)
+	if len(sigma) != n {
+		panic("fastrand: covariance matrix has the wrong dimension")
+	}
+	for i, row := range sigma {
+		if len(row) != n {
+			panic("fastrand: covariance matrix has the wrong dimension")
+		}
+		for j := range row[:i] {
+			if row[j] != sigma[j][i] {
+				panic("fastrand: covariance matrix is not symmetric")
+			}
+		}
+	}
+
+	// Cholesky-Banachiewicz factorization.
+	l := make([][]float64, n)
+	var logDet float64
+	for i := range l {
+		l[i] = make([]float64, n)
+		for j := 0; j <= i; j++ {
+			sum := sigma[i][j]
+			for k := 0; k < j; k++ {
+				sum -= l[i][k] * l[j][k]
+			}
+			if i == j {
+				if !(sum > 0) {
+					panic("fastrand: covariance matrix is not positive definite")
+				}
+				l[i][i] = math.Sqrt(sum)
+				logDet += 2 * math.Log(l[i][i])
+			} else {
+				l[i][j] = sum / l[j][j]
+			}
+		}
+	}
+	return &Normal{
+		mu:     append([]float64(nil), mu...),
+		chol:   l,
+		logDet: logDet,
+	}
+}
+
+// Rand returns a random vector drawn from the distribution, as mu + L*z,
+// where L is the Cholesky factor of the covariance and z is a vector of
+// independent standard normal values.
+func (n *Normal) Rand() []float64 {
+	z := normalVector(len(n.mu), n.Src)
+	x := append([]float64(nil), n.mu...)
+	for i, row := range n.chol {
+		for j, l := range row[:i+1] {
+			x[i] += l * z[j]
+		}
+	}
+	return x
+}
+
+// PDF returns the probability density at x.
+func (n *Normal) PDF(x []float64) float64 {
+	if len(x) != len(n.mu) {
+		panic("fastrand: Normal dimension mismatch")
+	}
+	// Solve L*y = x-mu by forward substitution; the exponent is -|y|^2/2.
+	y := make([]float64, len(x))
+	var sq float64
+	for i, row := range n.chol {
+		s := x[i] - n.mu[i]
+		for j, l := range row[:i] {
+			s -= l * y[j]
+		}
+		y[i] = s / row[i]
+		sq += y[i] * y[i]
+	}
+	k := float64(len(x))
+	return math.Exp(-0.5 * (sq + n.logDet + k*math.Log(2*math.Pi)))
+}
+
+// Dim returns the dimension of the distribution.
+func (n *Normal) Dim() int {
+	return len(n.mu)
+}
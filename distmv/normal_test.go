@@ -0,0 +1,93 @@
+package distmv
+
+import (
+	"math"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand/distuv"
+)
+
+// TestNormal tests that Normal's marginals, and a linear combination of its
+// coordinates, have the expected normal distributions.
+func TestNormal(t *testing.T) {
+	const n = 10000
+	mu := []float64{1, -2, 0.5}
+	sigma := [][]float64{
+		{4, 1.2, -0.6},
+		{1.2, 1, 0.3},
+		{-0.6, 0.3, 2},
+	}
+	w := []float64{1, -2, 0.5}
+	d := NewNormal(mu, sigma)
+	if d.Dim() != 3 {
+		t.Fatalf("expected dimension 3, got %v", d.Dim())
+	}
+	samples := make([][]float64, n)
+	for i := range samples {
+		samples[i] = d.Rand()
+	}
+	for j := range mu {
+		xs := make([]float64, n)
+		for i, s := range samples {
+			xs[i] = s[j]
+		}
+		checkKS(t, "Normal marginal", xs, distuv.Normal{Mu: mu[j], Sigma: math.Sqrt(sigma[j][j])}.CDF)
+	}
+	// w.x is normal with mean w.mu and variance w^T sigma w.
+	var mean, variance float64
+	for i := range w {
+		mean += w[i] * mu[i]
+		for j := range w {
+			variance += w[i] * sigma[i][j] * w[j]
+		}
+	}
+	xs := make([]float64, n)
+	for i, s := range samples {
+		for j := range w {
+			xs[i] += w[j] * s[j]
+		}
+	}
+	checkKS(t, "Normal combination", xs, distuv.Normal{Mu: mean, Sigma: math.Sqrt(variance)}.CDF)
+}
+
+// TestNormalPDF tests that the Normal PDF is the product of the marginal
+// PDFs for a diagonal covariance, and matches a direct computation in two
+// dimensions.
+func TestNormalPDF(t *testing.T) {
+	d := NewNormal([]float64{1, 2}, [][]float64{{4, 0}, {0, 0.25}})
+	x := []float64{0.3, 2.2}
+	want := distuv.Normal{Mu: 1, Sigma: 2}.PDF(0.3) * distuv.Normal{Mu: 2, Sigma: 0.5}.PDF(2.2)
+	if p := d.PDF(x); math.Abs(p-want) > 1e-12*want {
+		t.Errorf("PDF(%v) = %v, expected %v", x, p, want)
+	}
+
+	// For a bivariate normal with unit variances and correlation rho.
+	const rho = 0.6
+	d = NewNormal([]float64{0, 0}, [][]float64{{1, rho}, {rho, 1}})
+	x = []float64{0.5, -1}
+	q := (x[0]*x[0] - 2*rho*x[0]*x[1] + x[1]*x[1]) / (1 - rho*rho)
+	want = math.Exp(-q/2) / (2 * math.Pi * math.Sqrt(1-rho*rho))
+	if p := d.PDF(x); math.Abs(p-want) > 1e-12*want {
+		t.Errorf("PDF(%v) = %v, expected %v", x, p, want)
+	}
+}
+
+// TestNewNormalPanics tests that NewNormal panics on invalid covariance
+// matrices.
+func TestNewNormalPanics(t *testing.T) {
+	for _, test := range []struct {
+		mu    []float64
+		sigma [][]float64
+	}{
+		{nil, nil},
+		{[]float64{0, 0}, [][]float64{{1, 0}}},
+		{[]float64{0, 0}, [][]float64{{1, 0}, {0}}},
+		{[]float64{0, 0}, [][]float64{{1, 0.5}, {0.4, 1}}},
+		{[]float64{0, 0}, [][]float64{{1, 1}, {1, 1}}},
+		{[]float64{0, 0}, [][]float64{{-1, 0}, {0, 1}}},
+	} {
+		if !panics(func() { NewNormal(test.mu, test.sigma) }) {
+			t.Errorf("expected panic for %v", test.sigma)
+		}
+	}
+}
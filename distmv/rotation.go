@@ -0,0 +1,107 @@
+package distmv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Rotation is the uniform (Haar) distribution on the orthogonal Dim x Dim
+// matrices. If Special is set, it is instead the uniform distribution on the
+// rotations, the orthogonal matrices with determinant 1.
+type Rotation struct {
+	Dim     int
+	Special bool
+	Src     *fastrand.Generator
+}
+
+// Rand returns a random matrix, indexed by row and then column.
+//
+// The matrix is the Q factor of the QR decomposition of a matrix of
+// independent normal values, as described by Mezzadri, "How to Generate
+// Random Matrices from the Classical Compact Groups" (2007). The
+// decomposition must be normalized so that R has a positive diagonal, or the
+// result is not uniform; Gram-Schmidt orthogonalization, used here, produces
+// that normalization directly. It panics if Dim < 1.
+func (r Rotation) Rand() [][]float64 {
+	checkDim(r.Dim)
+	n := r.Dim
+	// cols[j] is column j of the matrix, orthonormalized in place by the
+	// modified Gram-Schmidt process. A column that is numerically dependent
+	// on the previous ones is redrawn; this has probability zero in exact
+	// arithmetic.
+	cols := make([][]float64, n)
+	for j := 0; j < n; j++ {
+		for {
+			v := normalVector(n, r.Src)
+			for _, q := range cols[:j] {
+				var dot float64
+				for i := range v {
+					dot += q[i] * v[i]
+				}
+				for i := range v {
+					v[i] -= dot * q[i]
+				}
+			}
+			if l := norm(v); l > 1e-9 {
+				for i := range v {
+					v[i] /= l
+				}
+				cols[j] = v
+				break
+			}
+		}
+	}
+	// Negating a column flips the sign of the determinant, and maps the
+	// Haar measure on the orthogonal matrices with determinant -1 to the
+	// Haar measure on the rotations.
+	if r.Special && determinantSign(cols) < 0 {
+		for i := range cols[0] {
+			cols[0][i] = -cols[0][i]
+		}
+	}
+	m := make([][]float64, n)
+	for i := range m {
+		m[i] = make([]float64, n)
+		for j := range m[i] {
+			m[i][j] = cols[j][i]
+		}
+	}
+	return m
+}
+
+// determinantSign returns the sign of the determinant of the matrix with
+// the given columns, by Gaussian elimination with partial pivoting.
+func determinantSign(cols [][]float64) float64 {
+	n := len(cols)
+	a := make([][]float64, n)
+	for i := range a {
+		a[i] = append([]float64(nil), cols[i]...)
+	}
+	sign := 1.0
+	for k := 0; k < n; k++ {
+		p := k
+		for i := k + 1; i < n; i++ {
+			if math.Abs(a[i][k]) > math.Abs(a[p][k]) {
+				p = i
+			}
+		}
+		if a[p][k] == 0 {
+			return 0
+		}
+		if p != k {
+			a[p], a[k] = a[k], a[p]
+			sign = -sign
+		}
+		if a[k][k] < 0 {
+			sign = -sign
+		}
+		for i := k + 1; i < n; i++ {
+			f := a[i][k] / a[k][k]
+			for j := k; j < n; j++ {
+				a[i][j] -= f * a[k][j]
+			}
+		}
+	}
+	return sign
+}
@@ -0,0 +1,96 @@
+package distmv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRotationOrthogonal tests that Rotation returns orthogonal matrices,
+// with determinant 1 if Special is set.
+func TestRotationOrthogonal(t *testing.T) {
+	for _, dim := range []int{1, 2, 3, 6} {
+		for _, special := range []bool{false, true} {
+			r := Rotation{Dim: dim, Special: special}
+			for i := 0; i < 100; i++ {
+				m := r.Rand()
+				for a := range m {
+					for b := range m {
+						var dot float64
+						for k := range m {
+							dot += m[a][k] * m[b][k]
+						}
+						want := 0.0
+						if a == b {
+							want = 1
+						}
+						if math.Abs(dot-want) > 1e-9 {
+							t.Fatalf("Rotation(%v) returned non-orthogonal %v", dim, m)
+						}
+					}
+				}
+				cols := make([][]float64, dim)
+				for j := range cols {
+					cols[j] = make([]float64, dim)
+					for k := range cols[j] {
+						cols[j][k] = m[k][j]
+					}
+				}
+				if special && determinantSign(cols) != 1 {
+					t.Fatalf("special Rotation(%v) returned %v, with determinant -1", dim, m)
+				}
+			}
+		}
+	}
+}
+
+// TestRotationHaar tests that Rotation is uniform: the rotation angle of a
+// uniform 3-dimensional rotation has density (1-cos(θ))/π, and a uniform
+// orthogonal matrix has determinant -1 half the time.
+func TestRotationHaar(t *testing.T) {
+	const n = 10000
+	r := Rotation{Dim: 3, Special: true}
+	angles := make([]float64, n)
+	for i := range angles {
+		m := r.Rand()
+		trace := m[0][0] + m[1][1] + m[2][2]
+		angles[i] = math.Acos(math.Max(-1, math.Min(1, (trace-1)/2)))
+	}
+	checkKS(t, "Rotation", angles, func(x float64) float64 { return (x - math.Sin(x)) / math.Pi })
+
+	// The entries of a uniform orthogonal matrix all share the distribution
+	// of a coordinate of a uniform point on the sphere.
+	r = Rotation{Dim: 3}
+	var entries []float64
+	counts := make([]int, 2)
+	for i := 0; i < n; i++ {
+		m := r.Rand()
+		entries = append(entries, m[i%3][(i/3)%3])
+		cols := [][]float64{{m[0][0], m[1][0], m[2][0]}, {m[0][1], m[1][1], m[2][1]}, {m[0][2], m[1][2], m[2][2]}}
+		if determinantSign(cols) > 0 {
+			counts[0]++
+		} else {
+			counts[1]++
+		}
+	}
+	checkKS(t, "Rotation", entries, func(x float64) float64 { return (x + 1) / 2 })
+	checkUniformCounts(t, "Rotation determinants", counts)
+}
+
+// TestDeterminantSign tests the determinantSign function.
+func TestDeterminantSign(t *testing.T) {
+	for _, test := range []struct {
+		cols [][]float64
+		sign float64
+	}{
+		{[][]float64{{2}}, 1},
+		{[][]float64{{-2}}, -1},
+		{[][]float64{{0, 1}, {1, 0}}, -1},
+		{[][]float64{{1, 2}, {3, 4}}, -1},
+		{[][]float64{{1, 2}, {2, 4}}, 0},
+		{[][]float64{{0, 0, 1}, {0, 1, 0}, {-1, 0, 0}}, 1},
+	} {
+		if s := determinantSign(test.cols); s != test.sign {
+			t.Errorf("determinantSign(%v) = %v, expected %v", test.cols, s, test.sign)
+		}
+	}
+}
@@ -0,0 +1,295 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Binomial is the binomial distribution of the number of successes in N
+// independent trials with success probability P.
+type Binomial struct {
+	N   uint64
+	P   float64
+	Src *fastrand.Generator
+}
+
+const (
+	// exactBinomialMax is the largest N for which Binomial counts exact
+	// Bernoulli trials.
+	exactBinomialMax = 64
+
+	// exactBinomialHalfMax is the largest N for which Binomial with P = 1/2
+	// counts random bits.
+	exactBinomialHalfMax = 1 << 20
+
+	// btpeMin is the smallest value of N*min(P, 1-P) for which Binomial uses
+	// BTPE rather than inversion.
+	btpeMin = 30
+)
+
+// Rand returns a random value drawn from the distribution.
+//
+// Where possible, Rand is exact: for P = 1/2 it counts random bits, and for
+// N <= 64 it counts exact Bernoulli trials. Otherwise it uses inversion when
+// N*min(P, 1-P) < 30, and the BTPE algorithm of Kachitvichyanukul and
+// Schmeiser, "Binomial Random Variate Generation" (1988), above that. It
+// panics if P is not in [0,1].
+func (b Binomial) Rand() float64 {
+	if !probability(b.P) {
+		panic("fastrand: invalid Binomial parameters")
+	}
+	switch {
+	case b.P <= 0:
+		return 0
+	case b.P >= 1:
+		return float64(b.N)
+	case b.P == 0.5 && b.N <= exactBinomialHalfMax:
+		return float64(popcountBits(b.N, b.Src))
+	case b.N <= exactBinomialMax:
+		var count float64
+		for i := uint64(0); i < b.N; i++ {
+			if bernoulliExact(b.P, b.Src) {
+				count++
+			}
+		}
+		return count
+	}
+
+	// Sample with the smaller of P and 1-P, and flip the result if needed.
+	r := math.Min(b.P, 1-b.P)
+	var y float64
+	if float64(b.N)*r < btpeMin {
+		y = binomialInversion(float64(b.N), r, b.Src)
+	} else {
+		y = binomialBTPE(float64(b.N), r, b.Src)
+	}
+	if b.P > 0.5 {
+		y = float64(b.N) - y
+	}
+	return y
+}
+
+// binomialInversion draws from the binomial distribution with n trials and
+// success probability p <= 1/2 by sequential search of the CDF.
+func binomialInversion(n, p float64, src *fastrand.Generator) float64 {
+	q := 1 - p
+	qn := math.Exp(n * math.Log(q))
+	np := n * p
+	bound := math.Min(n, np+10*math.Sqrt(np*q+1))
+	for {
+		x, px, u := 0.0, qn, src.Float64()
+		for u > px {
+			x++
+			if x > bound {
+				break
+			}
+			u -= px
+			px = (n - x + 1) * p * px / (x * q)
+		}
+		if x <= bound {
+			return x
+		}
+	}
+}
+
+// binomialBTPE draws from the binomial distribution with n trials and success
+// probability p <= 1/2, where n*p >= 30, using the BTPE algorithm. The
+// majority of draws are accepted from a triangular region; the rest are
+// drawn from parallelogram and exponential regions and accepted by
+// comparison against the PMF.
+func binomialBTPE(n, p float64, src *fastrand.Generator) float64 {
+	q := 1 - p
+	nrq := n * p * q
+	fm := n*p + p
+	m := math.Floor(fm)
+	p1 := math.Floor(2.195*math.Sqrt(nrq)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl, xr := xm-p1, xm+p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*p)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+
+	for {
+		u := src.Float64() * p4
+		v := src.Float64()
+		var y float64
+		switch {
+		case u <= p1:
+			// Triangular region; always accepted.
+			return math.Floor(xm - p1*v + u)
+		case u <= p2:
+			// Parallelogram region.
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// Left exponential tail.
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v *= (u - p2) * laml
+		default:
+			// Right exponential tail.
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > n || v == 0 {
+				continue
+			}
+			v *= (u - p3) * lamr
+		}
+
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// Evaluate the ratio of PMFs f(y)/f(m) recursively.
+			s := p / q
+			a := s * (n + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return y
+			}
+			continue
+		}
+
+		// Squeeze using bounds on log(f(y)/f(m)), then fall back to
+		// Stirling's approximation.
+		rho := (k / nrq) * ((k*(k/3+0.625)+1.0/6)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		logV := math.Log(v)
+		if logV < t-rho {
+			return y
+		}
+		if logV > t+rho {
+			continue
+		}
+		x1, f1, z, w := y+1, m+1, n+1-m, n-y+1
+		stirling := func(x float64) float64 {
+			x2 := x * x
+			return (13680 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+		}
+		bound := xm*math.Log(f1/x1) + (n-m+0.5)*math.Log(z/w) + (y-m)*math.Log(w*p/(x1*q)) +
+			stirling(f1) + stirling(z) + stirling(x1) + stirling(w)
+		if logV <= bound {
+			return y
+		}
+	}
+}
+
+// PMF returns the probability of drawing x.
+func (b Binomial) PMF(x float64) float64 {
+	n := float64(b.N)
+	if !isCount(x) || x > n {
+		return 0
+	}
+	switch b.P {
+	case 0:
+		if x == 0 {
+			return 1
+		}
+		return 0
+	case 1:
+		if x == n {
+			return 1
+		}
+		return 0
+	}
+	return math.Exp(lchoose(n, x) + x*math.Log(b.P) + (n-x)*math.Log1p(-b.P))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (b Binomial) CDF(x float64) float64 {
+	n := float64(b.N)
+	switch {
+	case x < 0:
+		return 0
+	case x >= n:
+		return 1
+	}
+	k := math.Floor(x)
+	return betaIncReg(n-k, k+1, 1-b.P)
+}
+
+// Multinomial is the multinomial distribution of the number of times each
+// outcome occurs in N independent trials, where outcome i has probability
+// proportional to Weights[i].
+type Multinomial struct {
+	N       uint64
+	Weights []float64
+	Src     *fastrand.Generator
+}
+
+// Rand returns a random draw from the distribution, as the number of
+// occurrences of each outcome. Each count is drawn from a binomial
+// distribution, conditional on the counts before it. It panics if any weight
+// is negative or not finite, or if the weights do not have a positive, finite
+// sum.
+func (m Multinomial) Rand() []uint64 {
+	var total float64
+	for _, w := range m.Weights {
+		if !(w >= 0) || math.IsInf(w, 1) {
+			panic("fastrand: invalid Multinomial weights")
+		}
+		total += w
+	}
+	if !positive(total) {
+		panic("fastrand: invalid Multinomial weights")
+	}
+	counts := make([]uint64, len(m.Weights))
+	remaining := m.N
+	for i, w := range m.Weights {
+		if remaining == 0 {
+			break
+		}
+		if i == len(m.Weights)-1 || w >= total {
+			counts[i] = remaining
+			break
+		}
+		counts[i] = uint64(Binomial{N: remaining, P: w / total, Src: m.Src}.Rand())
+		remaining -= counts[i]
+		total -= w
+	}
+	return counts
+}
+
+// PMF returns the probability of drawing counts.
+func (m Multinomial) PMF(counts []uint64) float64 {
+	if len(counts) != len(m.Weights) {
+		return 0
+	}
+	var total float64
+	for _, w := range m.Weights {
+		total += w
+	}
+	var n uint64
+	logP, _ := math.Lgamma(float64(m.N) + 1)
+	for i, c := range counts {
+		n += c
+		lg, _ := math.Lgamma(float64(c) + 1)
+		logP -= lg
+		if c > 0 {
+			logP += float64(c) * math.Log(m.Weights[i]/total)
+		}
+	}
+	if n != m.N {
+		return 0
+	}
+	return math.Exp(logP)
+}
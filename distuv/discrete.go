@@ -0,0 +1,267 @@
+package distuv
+
+import (
+	"math"
+	"math/bits"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// bernoulliExact returns true with probability exactly p, for p in [0,1]. It
+// compares a lazily generated uniform random real u against the binary
+// expansion of p, which is finite since p is a float64, and returns u < p.
+// It consumes 2 words of randomness on average.
+func bernoulliExact(p float64, src *fastrand.Generator) bool {
+	if p <= 0 {
+		return false
+	} else if p >= 1 {
+		return true
+	}
+	// p = m * 2^(exp-53), where m has 53 significant bits. The binary
+	// expansion of p is -exp zeros followed by the bits of m.
+	frac, exp := math.Frexp(p)
+	m := uint64(frac * (1 << 53))
+	for zeros := -exp; zeros > 0; zeros -= 64 {
+		w := src.Uint64()
+		if zeros < 64 {
+			w &= 1<<uint(zeros) - 1
+		}
+		if w != 0 {
+			// u has a one where p has a zero, so u > p.
+			return false
+		}
+	}
+	// u and p agree so far; compare the next 53 bits. If these agree too,
+	// then u >= p, as the remaining bits of p are zero.
+	return src.Uint64()>>11 < m
+}
+
+// lchoose returns log(n choose k).
+func lchoose(n, k float64) float64 {
+	a, _ := math.Lgamma(n + 1)
+	b, _ := math.Lgamma(k + 1)
+	c, _ := math.Lgamma(n - k + 1)
+	return a - b - c
+}
+
+// isCount reports whether x is a non-negative integer.
+func isCount(x float64) bool {
+	return x >= 0 && x == math.Floor(x)
+}
+
+// probability reports whether p is in [0,1].
+func probability(p float64) bool {
+	return 0 <= p && p <= 1
+}
+
+// Bernoulli is the Bernoulli distribution, which is 1 with probability P and
+// 0 otherwise.
+type Bernoulli struct {
+	P   float64
+	Src *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. The probability of
+// returning 1 is exactly P, without floating point rounding. It panics if P
+// is not in [0,1].
+func (b Bernoulli) Rand() float64 {
+	if !probability(b.P) {
+		panic("fastrand: invalid Bernoulli parameters")
+	}
+	if bernoulliExact(b.P, b.Src) {
+		return 1
+	}
+	return 0
+}
+
+// PMF returns the probability of drawing x.
+func (b Bernoulli) PMF(x float64) float64 {
+	switch x {
+	case 0:
+		return 1 - b.P
+	case 1:
+		return b.P
+	}
+	return 0
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (b Bernoulli) CDF(x float64) float64 {
+	switch {
+	case x < 0:
+		return 0
+	case x < 1:
+		return 1 - b.P
+	}
+	return 1
+}
+
+// Geometric is the geometric distribution of the number of failures before
+// the first success, in independent trials with success probability P.
+type Geometric struct {
+	P   float64
+	Src *fastrand.Generator
+}
+
+// exactGeometricMin is the smallest success probability for which Geometric
+// simulates trials exactly, rather than inverting the CDF.
+const exactGeometricMin = 0.25
+
+// Rand returns a random value drawn from the distribution. If P >= 1/4, it
+// counts exact Bernoulli trials, so the result is free of floating point
+// rounding; otherwise it inverts the CDF. It panics if P is not in (0,1].
+func (g Geometric) Rand() float64 {
+	if !(0 < g.P && g.P <= 1) {
+		panic("fastrand: invalid Geometric parameters")
+	}
+	if g.P >= exactGeometricMin {
+		var failures float64
+		for !bernoulliExact(g.P, g.Src) {
+			failures++
+		}
+		return failures
+	}
+	return math.Floor(math.Log(uniformOpen(g.Src)) / math.Log1p(-g.P))
+}
+
+// PMF returns the probability of drawing x.
+func (g Geometric) PMF(x float64) float64 {
+	if !isCount(x) {
+		return 0
+	}
+	return g.P * math.Exp(x*math.Log1p(-g.P))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (g Geometric) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1((math.Floor(x) + 1) * math.Log1p(-g.P))
+}
+
+// NegativeBinomial is the negative binomial distribution of the number of
+// failures before the R-th success, in independent trials with success
+// probability P. R need not be an integer.
+type NegativeBinomial struct {
+	R   float64
+	P   float64
+	Src *fastrand.Generator
+}
+
+// exactNegativeBinomialMax is the largest R for which NegativeBinomial sums
+// geometric draws, rather than using a gamma-Poisson mixture.
+const exactNegativeBinomialMax = 16
+
+// Rand returns a random value drawn from the distribution. For small integer
+// R, it is the sum of R geometric draws; otherwise it is a Poisson draw whose
+// mean is gamma distributed. It panics if R is not positive and finite, or if
+// P is not in (0,1].
+func (nb NegativeBinomial) Rand() float64 {
+	if !positive(nb.R) || !(0 < nb.P && nb.P <= 1) {
+		panic("fastrand: invalid NegativeBinomial parameters")
+	}
+	if nb.R <= exactNegativeBinomialMax && isCount(nb.R) {
+		g := Geometric{P: nb.P, Src: nb.Src}
+		var sum float64
+		for i := 0; i < int(nb.R); i++ {
+			sum += g.Rand()
+		}
+		return sum
+	}
+	lambda := gammaRand(nb.R, nb.Src) * (1 - nb.P) / nb.P
+	return poissonRand(lambda, nb.Src)
+}
+
+// PMF returns the probability of drawing x.
+func (nb NegativeBinomial) PMF(x float64) float64 {
+	if !isCount(x) {
+		return 0
+	}
+	a, _ := math.Lgamma(x + nb.R)
+	b, _ := math.Lgamma(nb.R)
+	c, _ := math.Lgamma(x + 1)
+	return math.Exp(a - b - c + nb.R*math.Log(nb.P) + x*math.Log1p(-nb.P))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (nb NegativeBinomial) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return betaIncReg(nb.R, math.Floor(x)+1, nb.P)
+}
+
+// Hypergeometric is the hypergeometric distribution of the number of
+// successes in N draws without replacement from a population of size Total
+// containing Successes successes.
+type Hypergeometric struct {
+	Total     uint64
+	Successes uint64
+	N         uint64
+	Src       *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. It simulates the
+// draws exactly using integer arithmetic, in O(min(N, Total-N)) time. It
+// panics if Successes or N exceeds Total.
+func (h Hypergeometric) Rand() float64 {
+	if h.Successes > h.Total || h.N > h.Total {
+		panic("fastrand: invalid Hypergeometric parameters")
+	}
+	// Drawing n items is equivalent to leaving Total-n behind, so simulate
+	// whichever is smaller.
+	n, complement := h.N, false
+	if n > h.Total-n {
+		n, complement = h.Total-n, true
+	}
+	remaining, successes := h.Total, h.Successes
+	var drawn uint64
+	for i := uint64(0); i < n; i++ {
+		if h.Src.Uint64n(remaining) < successes {
+			drawn++
+			successes--
+		}
+		remaining--
+	}
+	if complement {
+		drawn = h.Successes - drawn
+	}
+	return float64(drawn)
+}
+
+// PMF returns the probability of drawing x.
+func (h Hypergeometric) PMF(x float64) float64 {
+	lo := float64(h.N) - float64(h.Total-h.Successes)
+	if !isCount(x) || x < lo || x > float64(h.Successes) || x > float64(h.N) {
+		return 0
+	}
+	total, succ, n := float64(h.Total), float64(h.Successes), float64(h.N)
+	return math.Exp(lchoose(succ, x) + lchoose(total-succ, n-x) - lchoose(total, n))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (h Hypergeometric) CDF(x float64) float64 {
+	var sum float64
+	for k := 0.0; k <= x && k <= float64(h.N); k++ {
+		sum += h.PMF(k)
+	}
+	return math.Min(sum, 1)
+}
+
+// popcountBits returns the number of set bits in n random bits, which is
+// binomially distributed with probability 1/2.
+func popcountBits(n uint64, src *fastrand.Generator) uint64 {
+	var count uint64
+	for ; n >= 64; n -= 64 {
+		count += uint64(bits.OnesCount64(src.Uint64()))
+	}
+	if n > 0 {
+		count += uint64(bits.OnesCount64(src.Uint64() & (1<<n - 1)))
+	}
+	return count
+}
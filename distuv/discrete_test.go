@@ -0,0 +1,288 @@
+package distuv
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// discrete is implemented by the univariate discrete distributions in this
+// package.
+type discrete interface {
+	Rand() float64
+	PMF(float64) float64
+	CDF(float64) float64
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkGoodnessOfFit draws iters values from d, and fails the test if a
+// chi-square test rejects d's PMF. Consecutive values are grouped into bins
+// with an expected count of at least 20; the first and last bins hold the
+// lower and upper tails.
+func checkGoodnessOfFit(t *testing.T, name string, d discrete, iters int) {
+	t.Helper()
+	minProb := 20 / float64(iters)
+
+	// Find the largest value lo with CDF(lo) < minProb, so that the values
+	// up to lo form the lower tail.
+	lo := -1
+	if d.CDF(0) < minProb {
+		lo = sort.Search(math.MaxInt32, func(k int) bool { return d.CDF(float64(k)) >= minProb }) - 1
+	}
+
+	// ends holds the largest value in each bin, and binProbs its
+	// probability. Bins end once they are heavy enough, until what remains
+	// is too light to split further. Summing the PMF within each bin, rather
+	// than differencing the CDF, keeps every bin probability non-negative.
+	var ends []int
+	var binProbs []float64
+	if lo >= 0 {
+		ends = append(ends, lo)
+		binProbs = append(binProbs, d.CDF(float64(lo)))
+	}
+	var cur float64
+	for k := lo + 1; 1-d.CDF(float64(k)) >= minProb; k++ {
+		cur += d.PMF(float64(k))
+		if cur >= minProb {
+			ends = append(ends, k)
+			binProbs = append(binProbs, cur)
+			cur = 0
+		}
+	}
+	var sum float64
+	for _, p := range binProbs {
+		sum += p
+	}
+	ends = append(ends, math.MaxInt)
+	binProbs = append(binProbs, math.Max(1-sum, 0))
+
+	counts := make([]int, len(ends))
+	for i := 0; i < iters; i++ {
+		x := d.Rand()
+		if !isCount(x) {
+			t.Fatalf("%v: drew non-integer %v", name, x)
+		}
+		counts[sort.SearchInts(ends, int(x))]++
+	}
+	var stat float64
+	for i, p := range binProbs {
+		e := p * float64(iters)
+		if e < 1e-9 {
+			if counts[i] != 0 {
+				t.Errorf("%v: drew %v values from a bin with probability %v", name, counts[i], p)
+			}
+			continue
+		}
+		stat += (float64(counts[i]) - e) * (float64(counts[i]) - e) / e
+	}
+	df := len(binProbs) - 1
+	if df < 1 {
+		df = 1
+	}
+	if crit := chiSquareCritical(df); stat > crit {
+		t.Errorf("%v: chi-square statistic %.2f exceeds critical value %.2f; counts %v, expected %v", name, stat, crit, counts, binProbs)
+	}
+}
+
+// checkCDF fails the test if d's CDF differs from the sum of its PMF.
+func checkCDF(t *testing.T, name string, d discrete, max int) {
+	t.Helper()
+	var sum float64
+	for k := 0; k <= max; k++ {
+		sum += d.PMF(float64(k))
+		if c := d.CDF(float64(k) + 0.5); math.Abs(c-sum) > 1e-9 {
+			t.Errorf("%v: CDF(%v) = %v, but PMF sums to %v", name, k, c, sum)
+			return
+		}
+	}
+}
+
+// TestBernoulliExact tests that bernoulliExact is exact for extreme and
+// subnormal probabilities, and has the correct frequency otherwise.
+func TestBernoulliExact(t *testing.T) {
+	for i := 0; i < 1000; i++ {
+		if bernoulliExact(0, nil) || !bernoulliExact(1, nil) {
+			t.Fatal("bernoulliExact is wrong for p in {0, 1}")
+		}
+		if bernoulliExact(math.SmallestNonzeroFloat64, nil) || bernoulliExact(0x1p-70, nil) {
+			t.Fatal("bernoulliExact returned true for a negligible probability")
+		}
+		if !bernoulliExact(1-0x1p-53, nil) {
+			t.Fatal("bernoulliExact returned false for a probability of almost 1")
+		}
+	}
+	for _, p := range []float64{0.5, 0.625, 0.1, 1.0 / 3} {
+		checkGoodnessOfFit(t, "Bernoulli", Bernoulli{P: p}, 20000)
+	}
+}
+
+// TestBinomial tests each of Binomial's sampling methods.
+func TestBinomial(t *testing.T) {
+	for _, d := range []Binomial{
+		{N: 0, P: 0.3},
+		{N: 10, P: 0},
+		{N: 10, P: 1},
+		{N: 1000, P: 0.5},      // counting bits
+		{N: 20, P: 0.3},        // exact trials
+		{N: 64, P: 0.9},        // exact trials
+		{N: 1000, P: 0.01},     // inversion
+		{N: 500, P: 0.97},      // inversion, flipped
+		{N: 200, P: 0.3},       // BTPE
+		{N: 100000, P: 0.2},    // BTPE, far tails use the Stirling bound
+		{N: 1 << 30, P: 0.5},   // BTPE, too many trials to count bits
+		{N: 5000, P: 0.999999}, // inversion, flipped, tiny mean
+	} {
+		checkGoodnessOfFit(t, "Binomial", d, 20000)
+	}
+	checkCDF(t, "Binomial", Binomial{N: 50, P: 0.3}, 50)
+}
+
+// TestPoisson tests both of Poisson's sampling methods.
+func TestPoisson(t *testing.T) {
+	for _, lambda := range []float64{0.1, 1, 9.9, 10, 35, 1e4} {
+		checkGoodnessOfFit(t, "Poisson", Poisson{Lambda: lambda}, 20000)
+	}
+	if x := (Poisson{Lambda: 0}).Rand(); x != 0 {
+		t.Errorf("Poisson with mean 0 returned %v", x)
+	}
+	checkCDF(t, "Poisson", Poisson{Lambda: 4.5}, 30)
+}
+
+// TestGeometric tests both of Geometric's sampling methods.
+func TestGeometric(t *testing.T) {
+	for _, p := range []float64{1, 0.9, 0.25, 0.2, 0.01} {
+		checkGoodnessOfFit(t, "Geometric", Geometric{P: p}, 20000)
+	}
+	checkCDF(t, "Geometric", Geometric{P: 0.3}, 30)
+}
+
+// TestNegativeBinomial tests both of NegativeBinomial's sampling methods.
+func TestNegativeBinomial(t *testing.T) {
+	for _, d := range []NegativeBinomial{
+		{R: 1, P: 0.5},
+		{R: 5, P: 0.3},
+		{R: 2.5, P: 0.4},
+		{R: 40, P: 0.8},
+	} {
+		checkGoodnessOfFit(t, "NegativeBinomial", d, 20000)
+	}
+	checkCDF(t, "NegativeBinomial", NegativeBinomial{R: 3, P: 0.4}, 30)
+}
+
+// TestHypergeometric tests the Hypergeometric distribution, simulating both
+// the draws and their complement.
+func TestHypergeometric(t *testing.T) {
+	for _, d := range []Hypergeometric{
+		{Total: 50, Successes: 10, N: 5},
+		{Total: 50, Successes: 10, N: 45},
+		{Total: 20, Successes: 15, N: 10},
+		{Total: 1000, Successes: 0, N: 10},
+	} {
+		checkGoodnessOfFit(t, "Hypergeometric", d, 20000)
+	}
+	checkCDF(t, "Hypergeometric", Hypergeometric{Total: 30, Successes: 12, N: 8}, 8)
+}
+
+// TestDiscretePanics tests that the discrete distributions panic on invalid
+// parameters, including Hypergeometric draws of more items or successes than
+// the population holds.
+func TestDiscretePanics(t *testing.T) {
+	nan := math.NaN()
+	for _, d := range []discrete{
+		Bernoulli{P: 1.5},
+		Bernoulli{P: nan},
+		Binomial{N: 10, P: -0.1},
+		Binomial{N: 10, P: nan},
+		Poisson{Lambda: -1},
+		Poisson{Lambda: math.Inf(1)},
+		Geometric{P: 0},
+		Geometric{P: nan},
+		Geometric{P: 1.5},
+		NegativeBinomial{R: 0, P: 0.5},
+		NegativeBinomial{R: 3, P: 0},
+		NegativeBinomial{R: 2.5, P: nan},
+		Hypergeometric{Total: 10, Successes: 5, N: 11},
+		Hypergeometric{Total: 10, Successes: 11, N: 5},
+		Hypergeometric{Total: 0, Successes: 0, N: 1},
+	} {
+		if !panics(func() { d.Rand() }) {
+			t.Errorf("expected panic for %+v", d)
+		}
+	}
+	for _, w := range [][]float64{
+		{1, -1, 2},
+		{1, nan},
+		{1, math.Inf(1)},
+		{0, 0},
+		{},
+	} {
+		if !panics(func() { Multinomial{N: 3, Weights: w}.Rand() }) {
+			t.Errorf("expected panic for Multinomial weights %v", w)
+		}
+	}
+}
+
+// TestMultinomial tests that Multinomial draws every outcome with its
+// probability.
+func TestMultinomial(t *testing.T) {
+	d := Multinomial{N: 5, Weights: []float64{1, 2, 0, 3}}
+	index := make(map[[4]uint64]int)
+	var probs []float64
+	for a := uint64(0); a <= 5; a++ {
+		for b := uint64(0); a+b <= 5; b++ {
+			c := [4]uint64{a, b, 0, 5 - a - b}
+			index[c] = len(probs)
+			probs = append(probs, d.PMF(c[:]))
+		}
+	}
+	const iters = 40000
+	counts := make([]int, len(probs))
+	for i := 0; i < iters; i++ {
+		var draw [4]uint64
+		copy(draw[:], d.Rand())
+		j, ok := index[draw]
+		if !ok {
+			t.Fatalf("impossible draw %v", draw)
+		}
+		counts[j]++
+	}
+	var stat, total float64
+	for i, p := range probs {
+		total += p
+		e := p * iters
+		stat += (float64(counts[i]) - e) * (float64(counts[i]) - e) / e
+	}
+	if math.Abs(total-1) > 1e-12 {
+		t.Errorf("PMF sums to %v", total)
+	}
+	if crit := chiSquareCritical(len(probs) - 1); stat > crit {
+		t.Errorf("chi-square statistic %.2f exceeds critical value %.2f", stat, crit)
+	}
+}
+
+// BenchmarkBinomialBTPE benchmarks drawing from a large Binomial
+// distribution.
+func BenchmarkBinomialBTPE(b *testing.B) {
+	d := Binomial{N: 1e6, P: 0.3}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
+
+// BenchmarkPoissonPTRS benchmarks drawing from a Poisson distribution with a
+// large mean.
+func BenchmarkPoissonPTRS(b *testing.B) {
+	d := Poisson{Lambda: 1e3}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
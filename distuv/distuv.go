@@ -0,0 +1,35 @@
+// Package distuv provides univariate probability distributions. Each
+// distribution can draw random values with Rand, and evaluate its density
+// and cumulative distribution with PDF and CDF.
+//
+// Every distribution has a Src field, which is the fastrand.Generator used by
+// Rand. A nil Src draws from fastrand.Reader, so the zero value of Src is
+// cryptographically strong; a Generator with a deterministic source can be
+// used for reproducible simulations.
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// finite reports whether x is neither infinite nor NaN.
+func finite(x float64) bool {
+	return !math.IsInf(x, 0) && !math.IsNaN(x)
+}
+
+// positive reports whether x is positive and finite.
+func positive(x float64) bool {
+	return x > 0 && !math.IsInf(x, 1)
+}
+
+// uniformOpen returns a uniform random float64 in (0,1), for use where a
+// value of exactly 0 would produce an infinity or NaN.
+func uniformOpen(src *fastrand.Generator) float64 {
+	for {
+		if u := src.Float64(); u > 0 {
+			return u
+		}
+	}
+}
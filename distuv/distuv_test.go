@@ -0,0 +1,170 @@
+package distuv
+
+import (
+	"crypto/sha256"
+	"encoding/binary"
+	"math"
+	"sort"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// dist is implemented by every distribution in this package.
+type dist interface {
+	Rand() float64
+	PDF(float64) float64
+	CDF(float64) float64
+}
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// ksCritical is the coefficient of the Kolmogorov-Smirnov critical value at
+// a significance level of about 1e-4.
+const ksCritical = 2.23
+
+// checkKS draws n values from d and fails the test if the Kolmogorov-Smirnov
+// statistic against d's CDF exceeds the critical value.
+func checkKS(t *testing.T, name string, d dist, n int) {
+	t.Helper()
+	xs := make([]float64, n)
+	for i := range xs {
+		xs[i] = d.Rand()
+	}
+	sort.Float64s(xs)
+	var stat float64
+	for i, x := range xs {
+		c := d.CDF(x)
+		stat = math.Max(stat, math.Max(float64(i+1)/float64(n)-c, c-float64(i)/float64(n)))
+	}
+	if crit := ksCritical / math.Sqrt(float64(n)); stat > crit {
+		t.Errorf("%v: Kolmogorov-Smirnov statistic %.4f exceeds critical value %.4f", name, stat, crit)
+	}
+}
+
+// checkPDF fails the test if the integral of d's PDF over [a,b], computed with
+// Simpson's rule, differs from CDF(b)-CDF(a).
+func checkPDF(t *testing.T, name string, d dist, a, b float64) {
+	t.Helper()
+	const steps = 2000
+	h := (b - a) / steps
+	sum := d.PDF(a) + d.PDF(b)
+	for i := 1; i < steps; i++ {
+		w := 2.0
+		if i%2 == 1 {
+			w = 4
+		}
+		sum += w * d.PDF(a+float64(i)*h)
+	}
+	integral := sum * h / 3
+	if want := d.CDF(b) - d.CDF(a); math.Abs(integral-want) > 1e-6 {
+		t.Errorf("%v: integral of PDF over [%v, %v] is %v, but CDF gives %v", name, a, b, integral, want)
+	}
+}
+
+// hashReader is a deterministic io.Reader, used to check that distributions
+// draw only from their Src.
+type hashReader struct {
+	seed    uint64
+	counter uint64
+	buf     []byte
+}
+
+func (r *hashReader) Read(b []byte) (int, error) {
+	n := 0
+	for n < len(b) {
+		if len(r.buf) == 0 {
+			var in [16]byte
+			binary.LittleEndian.PutUint64(in[:8], r.seed)
+			binary.LittleEndian.PutUint64(in[8:], r.counter)
+			r.counter++
+			sum := sha256.Sum256(in[:])
+			r.buf = sum[:]
+		}
+		c := copy(b[n:], r.buf)
+		r.buf = r.buf[c:]
+		n += c
+	}
+	return n, nil
+}
+
+// TestZiggurat tests that the ziggurat tables are well formed: the layers
+// should narrow monotonically up to the peak of the density, with the last
+// computed layer very close to it.
+func TestZiggurat(t *testing.T) {
+	for _, z := range []*ziggurat{normalZig, expZig} {
+		n := len(z.x) - 1
+		if z.x[0] <= z.x[1] {
+			t.Errorf("base layer narrower than tail start: %v <= %v", z.x[0], z.x[1])
+		}
+		for i := 1; i < n; i++ {
+			if !(z.x[i+1] < z.x[i]) || !(z.f[i+1] > z.f[i]) {
+				t.Fatalf("layer %v is not monotonic", i)
+			}
+		}
+		if z.x[n-1] > 0.3 || z.f[n] != 1 {
+			t.Errorf("top layer is malformed: x = %v, f = %v", z.x[n-1], z.f[n])
+		}
+	}
+}
+
+// TestSrc tests that each distribution draws only from its Src.
+func TestSrc(t *testing.T) {
+	makeDists := func(seed uint64) []dist {
+		src := fastrand.NewGenerator(&hashReader{seed: seed})
+		return []dist{
+			Normal{Mu: 0, Sigma: 1, Src: src},
+			Gamma{Alpha: 0.5, Beta: 1, Src: src},
+			Triangle{A: 0, B: 1, C: 0.5, Src: src},
+		}
+	}
+	a, b, c := makeDists(1), makeDists(1), makeDists(2)
+	for i := range a {
+		x, y, z := a[i].Rand(), b[i].Rand(), c[i].Rand()
+		if x != y {
+			t.Errorf("%T: same source produced %v and %v", a[i], x, y)
+		}
+		if x == z {
+			t.Errorf("%T: different sources both produced %v", a[i], x)
+		}
+	}
+}
+
+// TestContinuousPanics tests that the continuous distributions panic on
+// invalid parameters, rather than looping forever or returning NaN.
+func TestContinuousPanics(t *testing.T) {
+	nan, inf := math.NaN(), math.Inf(1)
+	for _, d := range []dist{
+		Normal{Mu: 0, Sigma: 0},
+		Normal{Mu: nan, Sigma: 1},
+		LogNormal{Mu: 0, Sigma: -1},
+		Exponential{Rate: 0},
+		Exponential{Rate: inf},
+		Weibull{K: 1, Lambda: nan},
+		Pareto{Xm: -1, Alpha: 1},
+		Gamma{Alpha: nan, Beta: 1},
+		Gamma{Alpha: -1, Beta: 1},
+		Gamma{Alpha: 1, Beta: 0},
+		ChiSquared{K: 0},
+		Beta{Alpha: 1, Beta: -2},
+		Beta{Alpha: nan, Beta: 1},
+		StudentsT{Mu: 0, Sigma: 1, Nu: 0},
+		StudentsT{Mu: 0, Sigma: 0, Nu: 1},
+		Triangle{A: 1, B: 0, C: 0.5},
+		Triangle{A: 0, B: 1, C: 2},
+		Triangle{A: 0, B: inf, C: 1},
+		Cauchy{X0: 0, Gamma: 0},
+		Uniform{Min: 1, Max: 1},
+	} {
+		if !panics(func() { d.Rand() }) {
+			t.Errorf("expected panic for %+v", d)
+		}
+	}
+}
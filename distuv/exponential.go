@@ -0,0 +1,108 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Exponential is the exponential distribution with rate Rate.
+type Exponential struct {
+	Rate float64
+	Src  *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, using the
+// ziggurat method. It panics if Rate is not positive and finite.
+func (e Exponential) Rand() float64 {
+	if !positive(e.Rate) {
+		panic("fastrand: invalid Exponential parameters")
+	}
+	return expFloat64(e.Src) / e.Rate
+}
+
+// PDF returns the probability density at x.
+func (e Exponential) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return e.Rate * math.Exp(-e.Rate*x)
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (e Exponential) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-e.Rate * x)
+}
+
+// Weibull is the Weibull distribution with shape K and scale Lambda.
+type Weibull struct {
+	K      float64
+	Lambda float64
+	Src    *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. It panics if K or
+// Lambda is not positive and finite.
+func (w Weibull) Rand() float64 {
+	if !positive(w.K) || !positive(w.Lambda) {
+		panic("fastrand: invalid Weibull parameters")
+	}
+	return w.Lambda * math.Pow(expFloat64(w.Src), 1/w.K)
+}
+
+// PDF returns the probability density at x.
+func (w Weibull) PDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	z := x / w.Lambda
+	return w.K / w.Lambda * math.Pow(z, w.K-1) * math.Exp(-math.Pow(z, w.K))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (w Weibull) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return -math.Expm1(-math.Pow(x/w.Lambda, w.K))
+}
+
+// Pareto is the Pareto distribution with scale Xm and shape Alpha, whose
+// support is [Xm, inf).
+type Pareto struct {
+	Xm    float64
+	Alpha float64
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. It panics if Xm
+// or Alpha is not positive and finite.
+func (p Pareto) Rand() float64 {
+	if !positive(p.Xm) || !positive(p.Alpha) {
+		panic("fastrand: invalid Pareto parameters")
+	}
+	// If E is exponential with rate Alpha, Xm*exp(E) is Pareto.
+	return p.Xm * math.Exp(expFloat64(p.Src)/p.Alpha)
+}
+
+// PDF returns the probability density at x.
+func (p Pareto) PDF(x float64) float64 {
+	if x < p.Xm {
+		return 0
+	}
+	return p.Alpha * math.Pow(p.Xm, p.Alpha) / math.Pow(x, p.Alpha+1)
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (p Pareto) CDF(x float64) float64 {
+	if x < p.Xm {
+		return 0
+	}
+	return 1 - math.Pow(p.Xm/x, p.Alpha)
+}
@@ -0,0 +1,42 @@
+package distuv
+
+import (
+	"testing"
+)
+
+// TestExponential tests the Exponential distribution.
+func TestExponential(t *testing.T) {
+	for _, d := range []Exponential{{Rate: 1}, {Rate: 0.1}, {Rate: 25}} {
+		checkKS(t, "Exponential", d, 20000)
+		checkPDF(t, "Exponential", d, 0, 3/d.Rate)
+	}
+	// Sample far enough into the tail to exercise the ziggurat's base.
+	checkKS(t, "Exponential", Exponential{Rate: 1}, 200000)
+}
+
+// TestWeibull tests the Weibull distribution.
+func TestWeibull(t *testing.T) {
+	for _, d := range []Weibull{{K: 0.5, Lambda: 1}, {K: 1.5, Lambda: 2}, {K: 5, Lambda: 1}} {
+		checkKS(t, "Weibull", d, 20000)
+		checkPDF(t, "Weibull", d, 0.1, 3)
+	}
+}
+
+// TestPareto tests the Pareto distribution.
+func TestPareto(t *testing.T) {
+	for _, d := range []Pareto{{Xm: 1, Alpha: 3}, {Xm: 0.5, Alpha: 0.8}} {
+		checkKS(t, "Pareto", d, 20000)
+		checkPDF(t, "Pareto", d, d.Xm, 4*d.Xm)
+		if d.PDF(d.Xm/2) != 0 || d.CDF(d.Xm/2) != 0 {
+			t.Error("expected zero density and probability below Xm")
+		}
+	}
+}
+
+// BenchmarkExponential benchmarks drawing from the Exponential distribution.
+func BenchmarkExponential(b *testing.B) {
+	d := Exponential{Rate: 1}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
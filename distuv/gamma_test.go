@@ -0,0 +1,88 @@
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestSpecialFunctions tests the incomplete gamma and beta functions against
+// known values.
+func TestSpecialFunctions(t *testing.T) {
+	tests := []struct {
+		got, want float64
+	}{
+		// P(1, x) = 1 - exp(-x).
+		{gammaIncReg(1, 2), 1 - math.Exp(-2)},
+		// P(0.5, x) = erf(sqrt(x)).
+		{gammaIncReg(0.5, 0.3), math.Erf(math.Sqrt(0.3))},
+		{gammaIncReg(0.5, 9), math.Erf(3)},
+		// P(3, 10), from the CDF of a Poisson distribution.
+		{gammaIncReg(3, 10), 1 - math.Exp(-10)*(1+10+50)},
+		// I_x(1, 1) = x, and I_x(a, 1) = x^a.
+		{betaIncReg(1, 1, 0.3), 0.3},
+		{betaIncReg(2.5, 1, 0.6), math.Pow(0.6, 2.5)},
+		// I_x(a, b) = 1 - I_{1-x}(b, a).
+		{betaIncReg(2, 5, 0.8), 1 - betaIncReg(5, 2, 0.2)},
+		// I_0.5(a, a) = 0.5.
+		{betaIncReg(7.5, 7.5, 0.5), 0.5},
+	}
+	for i, test := range tests {
+		if math.Abs(test.got-test.want) > 1e-12 {
+			t.Errorf("test %v: got %v, want %v", i, test.got, test.want)
+		}
+	}
+}
+
+// TestGamma tests the Gamma distribution, including shapes below 1.
+func TestGamma(t *testing.T) {
+	for _, d := range []Gamma{
+		{Alpha: 0.3, Beta: 1},
+		{Alpha: 1, Beta: 2},
+		{Alpha: 2.5, Beta: 0.5},
+		{Alpha: 100, Beta: 10},
+	} {
+		checkKS(t, "Gamma", d, 20000)
+		mean := d.Alpha / d.Beta
+		checkPDF(t, "Gamma", d, mean/2, 2*mean)
+	}
+}
+
+// TestChiSquared tests the ChiSquared distribution.
+func TestChiSquared(t *testing.T) {
+	for _, d := range []ChiSquared{{K: 1}, {K: 4}, {K: 30}} {
+		checkKS(t, "ChiSquared", d, 20000)
+		checkPDF(t, "ChiSquared", d, d.K/2, 2*d.K)
+	}
+}
+
+// TestBeta tests the Beta distribution.
+func TestBeta(t *testing.T) {
+	for _, d := range []Beta{
+		{Alpha: 0.5, Beta: 0.5},
+		{Alpha: 2, Beta: 5},
+		{Alpha: 10, Beta: 1.5},
+	} {
+		checkKS(t, "Beta", d, 20000)
+		checkPDF(t, "Beta", d, 0.1, 0.9)
+	}
+}
+
+// TestStudentsT tests the StudentsT distribution.
+func TestStudentsT(t *testing.T) {
+	for _, d := range []StudentsT{
+		{Mu: 0, Sigma: 1, Nu: 1},
+		{Mu: 2, Sigma: 0.5, Nu: 3},
+		{Mu: 0, Sigma: 1, Nu: 50},
+	} {
+		checkKS(t, "StudentsT", d, 20000)
+		checkPDF(t, "StudentsT", d, d.Mu-2*d.Sigma, d.Mu+3*d.Sigma)
+	}
+}
+
+// BenchmarkGamma benchmarks drawing from the Gamma distribution.
+func BenchmarkGamma(b *testing.B) {
+	d := Gamma{Alpha: 2.5, Beta: 1}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
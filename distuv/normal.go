@@ -0,0 +1,72 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Normal is the normal (Gaussian) distribution with mean Mu and standard
+// deviation Sigma.
+type Normal struct {
+	Mu    float64
+	Sigma float64
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, using the
+// ziggurat method. It panics if Mu is not finite, or if Sigma is not positive
+// and finite.
+func (n Normal) Rand() float64 {
+	if !finite(n.Mu) || !positive(n.Sigma) {
+		panic("fastrand: invalid Normal parameters")
+	}
+	return n.Mu + n.Sigma*normFloat64(n.Src)
+}
+
+// PDF returns the probability density at x.
+func (n Normal) PDF(x float64) float64 {
+	z := (x - n.Mu) / n.Sigma
+	return math.Exp(-z*z/2) / (n.Sigma * math.Sqrt(2*math.Pi))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (n Normal) CDF(x float64) float64 {
+	return math.Erfc(-(x-n.Mu)/(n.Sigma*math.Sqrt2)) / 2
+}
+
+// LogNormal is the distribution of exp(X), where X is normally distributed
+// with mean Mu and standard deviation Sigma.
+type LogNormal struct {
+	Mu    float64
+	Sigma float64
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. It panics if Mu
+// is not finite, or if Sigma is not positive and finite.
+func (l LogNormal) Rand() float64 {
+	if !finite(l.Mu) || !positive(l.Sigma) {
+		panic("fastrand: invalid LogNormal parameters")
+	}
+	return math.Exp(l.Mu + l.Sigma*normFloat64(l.Src))
+}
+
+// PDF returns the probability density at x.
+func (l LogNormal) PDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	z := (math.Log(x) - l.Mu) / l.Sigma
+	return math.Exp(-z*z/2) / (x * l.Sigma * math.Sqrt(2*math.Pi))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (l LogNormal) CDF(x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	return math.Erfc(-(math.Log(x)-l.Mu)/(l.Sigma*math.Sqrt2)) / 2
+}
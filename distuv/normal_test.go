@@ -0,0 +1,52 @@
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestNormal tests the Normal distribution.
+func TestNormal(t *testing.T) {
+	for _, d := range []Normal{
+		{Mu: 0, Sigma: 1},
+		{Mu: -3, Sigma: 0.5},
+		{Mu: 1e3, Sigma: 20},
+	} {
+		checkKS(t, "Normal", d, 20000)
+		checkPDF(t, "Normal", d, d.Mu-3*d.Sigma, d.Mu+2*d.Sigma)
+	}
+	if c := (Normal{Mu: 0, Sigma: 1}).CDF(1.96); math.Abs(c-0.9750021048517795) > 1e-12 {
+		t.Errorf("unexpected Normal CDF: %v", c)
+	}
+
+	// The tail beyond the ziggurat's base should be sampled at the
+	// correct rate: P(|Z| > 3.5) ~= 4.65e-4.
+	const iters = 1e6
+	var tail int
+	for i := 0; i < iters; i++ {
+		if math.Abs(normFloat64(nil)) > 3.5 {
+			tail++
+		}
+	}
+	if exp := 4.6525e-4 * iters; math.Abs(float64(tail)-exp) > 5*math.Sqrt(exp) {
+		t.Errorf("saw %v tail values, expected %v", tail, exp)
+	}
+}
+
+// TestLogNormal tests the LogNormal distribution.
+func TestLogNormal(t *testing.T) {
+	d := LogNormal{Mu: 0.5, Sigma: 0.75}
+	checkKS(t, "LogNormal", d, 20000)
+	checkPDF(t, "LogNormal", d, 0.1, 5)
+	if d.PDF(-1) != 0 || d.CDF(-1) != 0 {
+		t.Error("expected zero density and probability below 0")
+	}
+}
+
+// BenchmarkNormal benchmarks drawing from the Normal distribution.
+func BenchmarkNormal(b *testing.B) {
+	d := Normal{Mu: 0, Sigma: 1}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
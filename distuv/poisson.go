@@ -0,0 +1,85 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Poisson is the Poisson distribution with mean Lambda.
+type Poisson struct {
+	Lambda float64
+	Src    *fastrand.Generator
+}
+
+// ptrsMin is the smallest mean for which Poisson uses PTRS rather than
+// multiplying uniforms.
+const ptrsMin = 10
+
+// Rand returns a random value drawn from the distribution. For means below
+// 10 it multiplies uniforms until the product falls below exp(-Lambda);
+// above that it uses the PTRS algorithm of Hörmann, "The Transformed
+// Rejection Method for Generating Poisson Random Variables" (1993). It
+// panics if Lambda is negative or not finite.
+func (p Poisson) Rand() float64 {
+	if !(p.Lambda >= 0) || math.IsInf(p.Lambda, 1) {
+		panic("fastrand: invalid Poisson parameters")
+	}
+	return poissonRand(p.Lambda, p.Src)
+}
+
+// poissonRand draws from the Poisson distribution with mean lambda.
+func poissonRand(lambda float64, src *fastrand.Generator) float64 {
+	if lambda <= 0 {
+		return 0
+	}
+	if lambda < ptrsMin {
+		limit := math.Exp(-lambda)
+		var x float64
+		for prod := src.Float64(); prod > limit; prod *= src.Float64() {
+			x++
+		}
+		return x
+	}
+
+	slam := math.Sqrt(lambda)
+	logLam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invAlpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := src.Float64() - 0.5
+		v := src.Float64()
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		if us >= 0.07 && v <= vr {
+			return k
+		}
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invAlpha)-math.Log(a/(us*us)+b) <= -lambda+k*logLam-lg {
+			return k
+		}
+	}
+}
+
+// PMF returns the probability of drawing x.
+func (p Poisson) PMF(x float64) float64 {
+	if !isCount(x) {
+		return 0
+	}
+	lg, _ := math.Lgamma(x + 1)
+	return math.Exp(x*math.Log(p.Lambda) - p.Lambda - lg)
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (p Poisson) CDF(x float64) float64 {
+	if x < 0 {
+		return 0
+	}
+	return 1 - gammaIncReg(math.Floor(x)+1, p.Lambda)
+}
@@ -0,0 +1,118 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Uniform is the continuous uniform distribution on [Min, Max).
+type Uniform struct {
+	Min float64
+	Max float64
+	Src *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. Like
+// fastrand.FloatRange, it never returns Max, even where rounding would
+// produce it. It panics if Min >= Max or if either is not finite.
+func (u Uniform) Rand() float64 {
+	return u.Src.FloatRange(u.Min, u.Max)
+}
+
+// PDF returns the probability density at x.
+func (u Uniform) PDF(x float64) float64 {
+	if x < u.Min || x >= u.Max {
+		return 0
+	}
+	return 1 / (u.Max - u.Min)
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (u Uniform) CDF(x float64) float64 {
+	switch {
+	case x < u.Min:
+		return 0
+	case x >= u.Max:
+		return 1
+	}
+	return (x - u.Min) / (u.Max - u.Min)
+}
+
+// Triangle is the triangular distribution with lower limit A, upper limit B
+// and mode C, where A <= C <= B.
+type Triangle struct {
+	A, B, C float64
+	Src     *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, by inverting its
+// CDF. It panics unless A < B and A <= C <= B, with all three finite.
+func (t Triangle) Rand() float64 {
+	if !(t.A < t.B && t.A <= t.C && t.C <= t.B) || !finite(t.A) || !finite(t.B) {
+		panic("fastrand: invalid Triangle parameters")
+	}
+	u := t.Src.Float64()
+	if u < (t.C-t.A)/(t.B-t.A) {
+		return t.A + math.Sqrt(u*(t.B-t.A)*(t.C-t.A))
+	}
+	return t.B - math.Sqrt((1-u)*(t.B-t.A)*(t.B-t.C))
+}
+
+// PDF returns the probability density at x.
+func (t Triangle) PDF(x float64) float64 {
+	switch {
+	case x < t.A || x > t.B:
+		return 0
+	case x < t.C:
+		return 2 * (x - t.A) / ((t.B - t.A) * (t.C - t.A))
+	case x == t.C:
+		return 2 / (t.B - t.A)
+	default:
+		return 2 * (t.B - x) / ((t.B - t.A) * (t.B - t.C))
+	}
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (t Triangle) CDF(x float64) float64 {
+	switch {
+	case x <= t.A:
+		return 0
+	case x >= t.B:
+		return 1
+	case x <= t.C:
+		return (x - t.A) * (x - t.A) / ((t.B - t.A) * (t.C - t.A))
+	default:
+		return 1 - (t.B-x)*(t.B-x)/((t.B-t.A)*(t.B-t.C))
+	}
+}
+
+// Cauchy is the Cauchy distribution with location X0 and scale Gamma.
+type Cauchy struct {
+	X0    float64
+	Gamma float64
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, by inverting its
+// CDF. It panics if X0 is not finite, or if Gamma is not positive and finite.
+func (c Cauchy) Rand() float64 {
+	if !finite(c.X0) || !positive(c.Gamma) {
+		panic("fastrand: invalid Cauchy parameters")
+	}
+	return c.X0 + c.Gamma*math.Tan(math.Pi*(uniformOpen(c.Src)-0.5))
+}
+
+// PDF returns the probability density at x.
+func (c Cauchy) PDF(x float64) float64 {
+	z := (x - c.X0) / c.Gamma
+	return 1 / (math.Pi * c.Gamma * (1 + z*z))
+}
+
+// CDF returns the probability that a value drawn from the distribution is
+// less than or equal to x.
+func (c Cauchy) CDF(x float64) float64 {
+	return math.Atan((x-c.X0)/c.Gamma)/math.Pi + 0.5
+}
@@ -0,0 +1,47 @@
+package distuv
+
+import (
+	"math"
+	"testing"
+)
+
+// TestUniform tests the Uniform distribution, including ranges where
+// rounding would produce Max.
+func TestUniform(t *testing.T) {
+	d := Uniform{Min: -2, Max: 5}
+	checkKS(t, "Uniform", d, 20000)
+	checkPDF(t, "Uniform", d, -1, 4)
+	for _, d := range []Uniform{
+		d,
+		{Min: 1, Max: math.Nextafter(1, 2)},
+		{Min: 1e16, Max: 1e16 + 2},
+	} {
+		for i := 0; i < 1000; i++ {
+			if x := d.Rand(); x < d.Min || x >= d.Max {
+				t.Fatalf("%+v returned %v", d, x)
+			}
+		}
+	}
+}
+
+// TestTriangle tests the Triangle distribution, including modes at either
+// limit.
+func TestTriangle(t *testing.T) {
+	for _, d := range []Triangle{
+		{A: 0, B: 1, C: 0.5},
+		{A: -1, B: 3, C: 0},
+		{A: 0, B: 1, C: 0},
+		{A: 0, B: 1, C: 1},
+	} {
+		checkKS(t, "Triangle", d, 20000)
+		checkPDF(t, "Triangle", d, d.A, d.C)
+		checkPDF(t, "Triangle", d, d.C, d.B)
+	}
+}
+
+// TestCauchy tests the Cauchy distribution.
+func TestCauchy(t *testing.T) {
+	d := Cauchy{X0: 1, Gamma: 2}
+	checkKS(t, "Cauchy", d, 20000)
+	checkPDF(t, "Cauchy", d, -5, 10)
+}
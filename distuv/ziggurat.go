@@ -0,0 +1,107 @@
+package distuv
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// The ziggurat method of Marsaglia and Tsang, "The Ziggurat Method for
+// Generating Random Variables" (2000), covers a density with layers of equal
+// area. A draw picks a layer and a point within it; almost always the point
+// lies under the density and is accepted immediately.
+//
+// A ziggurat is described by the x-coordinates of its layers. Layer i, for
+// 0 < i < len(x)-1, is a rectangle of width x[i] between heights f(x[i]) and
+// f(x[i+1]). Layer 0 is the base, of width x[0] = v/f(r) and height f(r),
+// which includes the tail beyond r = x[1].
+type ziggurat struct {
+	x []float64
+	f []float64 // f evaluated at each x
+}
+
+// newZiggurat computes a ziggurat with n layers for the decreasing density f
+// with inverse finv, given the tail start r and layer area v.
+func newZiggurat(n int, r, v float64, f, finv func(float64) float64) *ziggurat {
+	z := &ziggurat{
+		x: make([]float64, n+1),
+		f: make([]float64, n+1),
+	}
+	z.x[0] = v / f(r)
+	z.x[1] = r
+	for i := 1; i < n; i++ {
+		z.x[i+1] = finv(v/z.x[i] + f(z.x[i]))
+	}
+	z.x[n] = 0
+	for i := range z.x {
+		z.f[i] = f(z.x[i])
+	}
+	return z
+}
+
+var (
+	// normalZig covers exp(-x^2/2) on [0,inf) with 128 layers.
+	normalZig = newZiggurat(128, 3.442619855899, 9.91256303526217e-3,
+		func(x float64) float64 { return math.Exp(-x * x / 2) },
+		func(y float64) float64 { return math.Sqrt(-2 * math.Log(y)) },
+	)
+
+	// expZig covers exp(-x) on [0,inf) with 256 layers.
+	expZig = newZiggurat(256, 7.69711747013104972, 3.949659822581572e-3,
+		func(x float64) float64 { return math.Exp(-x) },
+		func(y float64) float64 { return -math.Log(y) },
+	)
+)
+
+// normFloat64 returns a standard normally distributed float64.
+func normFloat64(src *fastrand.Generator) float64 {
+	z := normalZig
+	r := z.x[1]
+	for {
+		w := src.Uint64()
+		i := int(w & 127)
+		x := float64(w>>11) / (1 << 53) * z.x[i]
+		if w&128 != 0 {
+			x = -x
+		}
+		if math.Abs(x) < z.x[i+1] {
+			return x
+		}
+		if i == 0 {
+			// Sample from the tail beyond r, using Marsaglia's method.
+			for {
+				a := -math.Log(uniformOpen(src)) / r
+				b := -math.Log(uniformOpen(src))
+				if 2*b >= a*a {
+					if x < 0 {
+						return -(r + a)
+					}
+					return r + a
+				}
+			}
+		}
+		if z.f[i]+src.Float64()*(z.f[i+1]-z.f[i]) < math.Exp(-x*x/2) {
+			return x
+		}
+	}
+}
+
+// expFloat64 returns an exponentially distributed float64 with rate 1.
+func expFloat64(src *fastrand.Generator) float64 {
+	z := expZig
+	for {
+		w := src.Uint64()
+		i := int(w & 255)
+		x := float64(w>>11) / (1 << 53) * z.x[i]
+		if x < z.x[i+1] {
+			return x
+		}
+		if i == 0 {
+			// The tail beyond r is itself exponential.
+			return z.x[1] - math.Log(uniformOpen(src))
+		}
+		if z.f[i]+src.Float64()*(z.f[i+1]-z.f[i]) < math.Exp(-x) {
+			return x
+		}
+	}
+}
@@ -0,0 +1,56 @@
+// Package dp provides noise for differentially private mechanisms.
+//
+// Floating-point implementations of the Laplace mechanism leak information
+// through the low bits of their output, as shown by Mironov, "On Significance
+// of the Least Significant Bits for Differential Privacy" (2012). This package
+// offers two remedies: the discrete Laplace and discrete Gaussian
+// distributions, which are sampled exactly using only integer and rational
+// arithmetic, and the snapping mechanism, which adds continuous Laplace noise
+// and then rounds and clamps the result to hide its low bits.
+//
+// Like the distributions in distuv, every sampler has a Src field, which is
+// the fastrand.Generator it draws from. A nil Src draws from fastrand.Reader.
+package dp
+
+import (
+	"math/big"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// bernoulliExp returns true with probability exactly exp(-gamma), for
+// gamma >= 0, using the method of Canonne, Kamath and Steinke, "The Discrete
+// Gaussian for Differential Privacy" (2020), Algorithm 1.
+func bernoulliExp(gamma *big.Rat, src *fastrand.Generator) bool {
+	// exp(-gamma) = exp(-1)^floor(gamma) * exp(-(gamma - floor(gamma))).
+	whole := new(big.Int).Quo(gamma.Num(), gamma.Denom())
+	one := big.NewRat(1, 1)
+	for i := new(big.Int); i.Cmp(whole) < 0; i.Add(i, big.NewInt(1)) {
+		if !bernoulliExpFrac(one, src) {
+			return false
+		}
+	}
+	return bernoulliExpFrac(new(big.Rat).Sub(gamma, new(big.Rat).SetInt(whole)), src)
+}
+
+// bernoulliExpFrac returns true with probability exactly exp(-gamma), for
+// gamma in [0,1]. It draws Bernoulli(gamma/k) for k = 1, 2, ... until one is
+// false; the probability that this happens at an odd k is exp(-gamma).
+func bernoulliExpFrac(gamma *big.Rat, src *fastrand.Generator) bool {
+	p := new(big.Rat)
+	for k := int64(1); ; k++ {
+		if !src.BernoulliRat(p.Quo(gamma, big.NewRat(k, 1))) {
+			return k%2 == 1
+		}
+	}
+}
+
+// toInt64 converts a sample to an int64, panicking if it does not fit. This
+// only happens for scales close to 2^63, where the samplers' integer range is
+// too small.
+func toInt64(x *big.Int) int64 {
+	if !x.IsInt64() {
+		panic("fastrand: dp sample overflows int64")
+	}
+	return x.Int64()
+}
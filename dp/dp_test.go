@@ -0,0 +1,99 @@
+package dp
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkSymmetricPMF draws iters values from rand, and fails the test if a
+// chi-square test rejects pmf, which must be symmetric about 0. Each value
+// with an expected count of at least 10 has its own bin, and the two tails
+// share the remaining probability.
+func checkSymmetricPMF(t *testing.T, name string, pmf func(int64) float64, rand func() int64, iters int) {
+	t.Helper()
+	m := int64(0)
+	for pmf(m+1)*float64(iters) >= 10 {
+		m++
+	}
+	probs := make([]float64, 2*m+3) // lower tail, -m, ..., m, upper tail
+	sum := 0.0
+	for x := -m; x <= m; x++ {
+		probs[x+m+1] = pmf(x)
+		sum += pmf(x)
+	}
+	probs[0] = math.Max(1-sum, 0) / 2
+	probs[len(probs)-1] = probs[0]
+
+	counts := make([]int, len(probs))
+	for i := 0; i < iters; i++ {
+		x := rand()
+		switch {
+		case x < -m:
+			counts[0]++
+		case x > m:
+			counts[len(counts)-1]++
+		default:
+			counts[x+m+1]++
+		}
+	}
+	var stat float64
+	for i, p := range probs {
+		e := p * float64(iters)
+		if e < 1e-9 {
+			if counts[i] != 0 {
+				t.Errorf("%v: drew %v values from a bin with probability %v", name, counts[i], p)
+			}
+			continue
+		}
+		stat += (float64(counts[i]) - e) * (float64(counts[i]) - e) / e
+	}
+	if crit := chiSquareCritical(len(probs) - 1); stat > crit {
+		t.Errorf("%v: chi-square statistic %.2f exceeds critical value %.2f; counts %v", name, stat, crit, counts)
+	}
+}
+
+// TestBernoulliExp tests that bernoulliExp returns true with probability
+// exp(-gamma), for gamma on either side of 1.
+func TestBernoulliExp(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if !bernoulliExp(new(big.Rat), nil) {
+			t.Fatal("bernoulliExp(0) returned false")
+		}
+	}
+	const iters = 40000
+	for _, gamma := range []*big.Rat{big.NewRat(1, 3), big.NewRat(1, 1), big.NewRat(5, 2), big.NewRat(6, 1)} {
+		var hits int
+		for i := 0; i < iters; i++ {
+			if bernoulliExp(gamma, nil) {
+				hits++
+			}
+		}
+		g, _ := gamma.Float64()
+		p := math.Exp(-g)
+		e := p * iters
+		stat := (float64(hits)-e)*(float64(hits)-e)/e + (float64(iters-hits)-(iters-e))*(float64(iters-hits)-(iters-e))/(iters-e)
+		if stat > chiSquareCritical(1) {
+			t.Errorf("bernoulliExp(%v): %v hits, expected %.1f", gamma, hits, e)
+		}
+	}
+}
@@ -0,0 +1,60 @@
+package dp
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// DiscreteGaussian is the discrete Gaussian distribution over the integers,
+// with P(x) proportional to exp(-x^2/(2*Sigma2)). It is sampled exactly.
+// Adding it to a query with sensitivity Δ satisfies (ε^2/2)-concentrated DP
+// for ε = Δ/sqrt(Sigma2).
+type DiscreteGaussian struct {
+	Sigma2 *big.Rat
+	Src    *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, using Algorithm 3
+// of Canonne, Kamath and Steinke: a discrete Laplace sample with scale
+// floor(sigma)+1 is accepted with a probability that reshapes it into a
+// discrete Gaussian. It panics if Sigma2 is not positive.
+func (d DiscreteGaussian) Rand() int64 {
+	if d.Sigma2.Sign() <= 0 {
+		panic("fastrand: DiscreteGaussian variance is not positive")
+	}
+	// floor(sqrt(q)) = isqrt(floor(q)) for any rational q >= 0.
+	t := new(big.Int).Quo(d.Sigma2.Num(), d.Sigma2.Denom())
+	t.Sqrt(t).Add(t, big.NewInt(1))
+	if !t.IsUint64() {
+		panic("fastrand: DiscreteGaussian variance overflows")
+	}
+	one := big.NewInt(1)
+
+	// Accept y with probability exp(-(|y| - Sigma2/t)^2 / (2*Sigma2)).
+	shift := new(big.Rat).Quo(d.Sigma2, new(big.Rat).SetInt(t))
+	twoSigma2 := new(big.Rat).Add(d.Sigma2, d.Sigma2)
+	gamma := new(big.Rat)
+	for {
+		y := discreteLaplace(t, one, d.Src)
+		gamma.SetInt(new(big.Int).Abs(y))
+		gamma.Sub(gamma, shift)
+		gamma.Mul(gamma, gamma)
+		gamma.Quo(gamma, twoSigma2)
+		if bernoulliExp(gamma, d.Src) {
+			return toInt64(y)
+		}
+	}
+}
+
+// PMF returns the probability of drawing x. The normalizing constant is
+// computed by summing the unnormalized PMF out to 40 standard deviations.
+func (d DiscreteGaussian) PMF(x int64) float64 {
+	s2, _ := d.Sigma2.Float64()
+	norm := 1.0
+	for k := 1.0; k <= 40*math.Sqrt(s2)+1; k++ {
+		norm += 2 * math.Exp(-k*k/(2*s2))
+	}
+	return math.Exp(-float64(x)*float64(x)/(2*s2)) / norm
+}
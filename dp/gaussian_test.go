@@ -0,0 +1,56 @@
+package dp
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestDiscreteGaussian tests the DiscreteGaussian distribution, including
+// variances below 1, where the underlying discrete Laplace has scale 1.
+func TestDiscreteGaussian(t *testing.T) {
+	for _, sigma2 := range []*big.Rat{big.NewRat(1, 4), big.NewRat(1, 1), big.NewRat(5, 2), big.NewRat(100, 1)} {
+		d := DiscreteGaussian{Sigma2: sigma2}
+		checkSymmetricPMF(t, "DiscreteGaussian", d.PMF, d.Rand, 20000)
+
+		// For sigma2 >= 1, the variance of the discrete Gaussian is within
+		// 1e-4 of sigma2.
+		if sigma2.Cmp(big.NewRat(1, 1)) < 0 {
+			continue
+		}
+		const iters = 40000
+		var sum, sumSq float64
+		for i := 0; i < iters; i++ {
+			x := float64(d.Rand())
+			sum += x
+			sumSq += x * x
+		}
+		s2, _ := sigma2.Float64()
+		mean := sum / iters
+		variance := sumSq/iters - mean*mean
+		// The sample variance has a standard deviation of about
+		// sigma2*sqrt(2/iters).
+		if math.Abs(variance-s2) > 5*s2*math.Sqrt(2.0/iters) {
+			t.Errorf("DiscreteGaussian(%v): sample variance %v", sigma2, variance)
+		}
+	}
+}
+
+// TestDiscreteGaussianPanics tests that DiscreteGaussian panics on invalid
+// variances.
+func TestDiscreteGaussianPanics(t *testing.T) {
+	for _, sigma2 := range []*big.Rat{new(big.Rat), big.NewRat(-1, 2)} {
+		if !panics(func() { DiscreteGaussian{Sigma2: sigma2}.Rand() }) {
+			t.Errorf("expected panic for variance %v", sigma2)
+		}
+	}
+}
+
+// BenchmarkDiscreteGaussian benchmarks DiscreteGaussian with a moderate
+// variance.
+func BenchmarkDiscreteGaussian(b *testing.B) {
+	d := DiscreteGaussian{Sigma2: big.NewRat(100, 1)}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
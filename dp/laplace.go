@@ -0,0 +1,128 @@
+package dp
+
+import (
+	"math"
+	"math/big"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// DiscreteLaplace is the discrete Laplace distribution over the integers,
+// with P(x) proportional to exp(-|x|/Scale). It is sampled exactly, so it is
+// a safe replacement for the Laplace mechanism on integer-valued queries:
+// adding noise with scale Δ/ε to a query with sensitivity Δ is ε-DP.
+type DiscreteLaplace struct {
+	Scale *big.Rat
+	Src   *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution, using Algorithm 2
+// of Canonne, Kamath and Steinke. It panics if Scale is not positive, or if
+// its numerator does not fit in a uint64.
+func (d DiscreteLaplace) Rand() int64 {
+	if d.Scale.Sign() <= 0 {
+		panic("fastrand: DiscreteLaplace scale is not positive")
+	}
+	if !d.Scale.Num().IsUint64() {
+		panic("fastrand: DiscreteLaplace scale numerator overflows uint64")
+	}
+	return toInt64(discreteLaplace(d.Scale.Num(), d.Scale.Denom(), d.Src))
+}
+
+// PMF returns the probability of drawing x.
+func (d DiscreteLaplace) PMF(x int64) float64 {
+	t, _ := d.Scale.Float64()
+	return math.Tanh(1/(2*t)) * math.Exp(-math.Abs(float64(x))/t)
+}
+
+// discreteLaplace returns a sample from the discrete Laplace distribution
+// with scale t/s, where t fits in a uint64.
+func discreteLaplace(t, s *big.Int, src *fastrand.Generator) *big.Int {
+	tn := t.Uint64()
+	u := new(big.Rat)
+	x := new(big.Int)
+	for {
+		// Draw x from the geometric distribution with parameter
+		// 1 - exp(-1/t), as u + t*v where u is uniform in [0,t) and v is
+		// geometric with parameter 1 - exp(-1).
+		un := src.Uint64n(tn)
+		if !bernoulliExp(u.SetFrac(new(big.Int).SetUint64(un), t), src) {
+			continue
+		}
+		v := int64(0)
+		for bernoulliExp(big.NewRat(1, 1), src) {
+			v++
+		}
+		x.Mul(t, big.NewInt(v)).Add(x, new(big.Int).SetUint64(un))
+
+		// Dividing by s rescales the geometric distribution to parameter
+		// 1 - exp(-s/t). A random sign then makes it symmetric, rejecting
+		// -0 so that 0 is not counted twice.
+		y := new(big.Int).Quo(x, s)
+		negative := src.Uint64()&1 == 1
+		if negative && y.Sign() == 0 {
+			continue
+		}
+		if negative {
+			y.Neg(y)
+		}
+		return y
+	}
+}
+
+// SnappingLaplace implements the snapping mechanism of Mironov, which adds
+// floating-point Laplace noise with scale Lambda to a value, then rounds the
+// result to a multiple of the smallest power of two at least Lambda and
+// clamps it to [-Bound,Bound]. The rounding hides the irregular low bits of
+// the noise, and the clamping bounds the error this introduces.
+//
+// For a query with sensitivity 1 whose value lies in [-Bound,Bound], the
+// mechanism is (1/Lambda + 2^-49*Bound/Lambda)-DP, provided Lambda < Bound <
+// 2^46*Lambda. The analysis assumes a correctly rounded logarithm; Go's
+// math.Log is accurate to within one ulp, which the rounding step absorbs in
+// practice but which is not covered by the proof.
+type SnappingLaplace struct {
+	Lambda float64
+	Bound  float64
+	Src    *fastrand.Generator
+}
+
+// Add returns x plus snapped Laplace noise. It panics if Lambda or Bound is
+// not positive and finite.
+func (s SnappingLaplace) Add(x float64) float64 {
+	if !(s.Lambda > 0) || math.IsInf(s.Lambda, 0) || !(s.Bound > 0) || math.IsInf(s.Bound, 0) {
+		panic("fastrand: invalid SnappingLaplace parameters")
+	}
+	// u is drawn from every float64 in (0,1), each with probability
+	// proportional to its spacing, as the analysis requires.
+	var u float64
+	for u == 0 {
+		u = s.Src.Float64Full()
+	}
+	noise := s.Lambda * math.Log(u)
+	if s.Src.Uint64()&1 == 1 {
+		noise = -noise
+	}
+	y := clamp(x, s.Bound) + noise
+	return clamp(roundTo(y, snapUnit(s.Lambda)), s.Bound)
+}
+
+// snapUnit returns the smallest power of two that is at least lambda.
+func snapUnit(lambda float64) float64 {
+	frac, exp := math.Frexp(lambda)
+	if frac == 0.5 {
+		return lambda
+	}
+	return math.Ldexp(1, exp)
+}
+
+// roundTo rounds x to the nearest multiple of unit, which must be a power of
+// two. Both the division and the multiplication are exact.
+func roundTo(x, unit float64) float64 {
+	return math.Round(x/unit) * unit
+}
+
+// clamp returns x clamped to [-bound,bound].
+func clamp(x, bound float64) float64 {
+	return math.Max(-bound, math.Min(bound, x))
+}
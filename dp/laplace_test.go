@@ -0,0 +1,125 @@
+package dp
+
+import (
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestDiscreteLaplace tests the DiscreteLaplace distribution for integer and
+// fractional scales.
+func TestDiscreteLaplace(t *testing.T) {
+	for _, scale := range []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 1), big.NewRat(3, 1), big.NewRat(10, 3)} {
+		d := DiscreteLaplace{Scale: scale}
+		var sum float64
+		for x := int64(-200); x <= 200; x++ {
+			sum += d.PMF(x)
+		}
+		if math.Abs(sum-1) > 1e-9 {
+			t.Errorf("DiscreteLaplace(%v): PMF sums to %v", scale, sum)
+		}
+		checkSymmetricPMF(t, "DiscreteLaplace", d.PMF, d.Rand, 20000)
+	}
+}
+
+// TestDiscreteLaplacePanics tests that DiscreteLaplace panics on invalid
+// scales.
+func TestDiscreteLaplacePanics(t *testing.T) {
+	huge := new(big.Int).Lsh(big.NewInt(1), 64)
+	for _, scale := range []*big.Rat{new(big.Rat), big.NewRat(-1, 2), new(big.Rat).SetInt(huge)} {
+		if !panics(func() { DiscreteLaplace{Scale: scale}.Rand() }) {
+			t.Errorf("expected panic for scale %v", scale)
+		}
+	}
+}
+
+// TestSnappingLaplace tests that the snapping mechanism's output is rounded
+// and clamped, and otherwise follows the Laplace distribution.
+func TestSnappingLaplace(t *testing.T) {
+	// With Lambda = 1, values are rounded to integers, so the probability
+	// of k is the Laplace probability of [k-1/2, k+1/2) around x.
+	const x = 0.3
+	s := SnappingLaplace{Lambda: 1, Bound: 100}
+	laplaceCDF := func(y float64) float64 {
+		if y < 0 {
+			return math.Exp(y) / 2
+		}
+		return 1 - math.Exp(-y)/2
+	}
+	pmf := func(k int64) float64 {
+		return laplaceCDF(float64(k)+0.5-x) - laplaceCDF(float64(k)-0.5-x)
+	}
+	// The shift by x makes the distribution asymmetric, so it is checked
+	// directly rather than with checkSymmetricPMF.
+	const iters = 40000
+	counts := make(map[int64]int)
+	for i := 0; i < iters; i++ {
+		y := s.Add(x)
+		if y != math.Trunc(y) || math.Abs(y) > s.Bound {
+			t.Fatalf("SnappingLaplace returned %v", y)
+		}
+		counts[int64(y)]++
+	}
+	var stat, rest float64
+	restCount := iters
+	for k := int64(-5); k <= 5; k++ {
+		e := pmf(k) * iters
+		stat += (float64(counts[k]) - e) * (float64(counts[k]) - e) / e
+		rest += pmf(k)
+		restCount -= counts[k]
+	}
+	e := (1 - rest) * iters
+	stat += (float64(restCount) - e) * (float64(restCount) - e) / e
+	if crit := chiSquareCritical(11); stat > crit {
+		t.Errorf("chi-square statistic %.2f exceeds critical value %.2f; counts %v", stat, crit, counts)
+	}
+
+	// With Lambda = 3, values are rounded to multiples of 4 and clamped.
+	s = SnappingLaplace{Lambda: 3, Bound: 10}
+	for i := 0; i < 10000; i++ {
+		switch y := s.Add(9); y {
+		case -10, -8, -4, 0, 4, 8, 10:
+		default:
+			t.Fatalf("SnappingLaplace returned %v", y)
+		}
+	}
+}
+
+// TestSnapUnit tests the snapUnit function.
+func TestSnapUnit(t *testing.T) {
+	for _, test := range []struct{ lambda, unit float64 }{
+		{1, 1},
+		{3, 4},
+		{4, 4},
+		{0.3, 0.5},
+		{0.25, 0.25},
+		{1e6, 1 << 20},
+	} {
+		if u := snapUnit(test.lambda); u != test.unit {
+			t.Errorf("snapUnit(%v) = %v, expected %v", test.lambda, u, test.unit)
+		}
+	}
+}
+
+// TestSnappingLaplacePanics tests that SnappingLaplace panics on invalid
+// parameters.
+func TestSnappingLaplacePanics(t *testing.T) {
+	for _, s := range []SnappingLaplace{
+		{Lambda: 0, Bound: 1},
+		{Lambda: 1, Bound: 0},
+		{Lambda: math.NaN(), Bound: 1},
+		{Lambda: 1, Bound: math.Inf(1)},
+	} {
+		if !panics(func() { s.Add(0) }) {
+			t.Errorf("expected panic for %+v", s)
+		}
+	}
+}
+
+// BenchmarkDiscreteLaplace benchmarks DiscreteLaplace with a moderate scale.
+func BenchmarkDiscreteLaplace(b *testing.B) {
+	d := DiscreteLaplace{Scale: big.NewRat(10, 1)}
+	for i := 0; i < b.N; i++ {
+		_ = d.Rand()
+	}
+}
@@ -172,3 +172,79 @@ func Perm(n int) []int {
 	}
 	return m
 }
+
+// CyclicPerm returns a random cyclic permutation of the integers [0,n), that
+// is, a permutation consisting of a single cycle of length n. Following m[i]
+// from any starting point visits every integer before returning to the
+// start. It uses Sattolo's algorithm, so every cyclic permutation is equally
+// likely.
+func CyclicPerm(n int) []int {
+	m := make([]int, n)
+	for i := range m {
+		m[i] = i
+	}
+	for i := n - 1; i > 0; i-- {
+		j := Intn(i)
+		m[i], m[j] = m[j], m[i]
+	}
+	return m
+}
+
+// Derangement returns a random permutation of the integers [0,n) with no
+// fixed points, that is, m[i] != i for all i. Every derangement is equally
+// likely. It panics if n == 1, as no such permutation exists.
+func Derangement(n int) []int {
+	if n == 1 {
+		panic("fastrand: no derangement of length 1 exists")
+	}
+	// About 1/e of all permutations are derangements, so rejection sampling
+	// takes an expected e tries.
+outer:
+	for {
+		m := Perm(n)
+		for i, j := range m {
+			if i == j {
+				continue outer
+			}
+		}
+		return m
+	}
+}
+
+// Involution returns a random involution of the integers [0,n), that is, a
+// permutation that is its own inverse, so m[m[i]] == i for all i. Every
+// involution is equally likely.
+func Involution(n int) []int {
+	// An involution consists of k disjoint pairs and n-2k fixed points. There
+	// are t(k) = n!/((n-2k)! k! 2^k) involutions with k pairs. Choose k with
+	// probability t(k)/sum(t), then pair up a random ordering of [0,n).
+	counts := make([]*big.Int, n/2+1)
+	total := new(big.Int)
+	t := big.NewInt(1)
+	for k := range counts {
+		counts[k] = t
+		total.Add(total, t)
+		t = new(big.Int).Mul(t, big.NewInt(int64((n-2*k)*(n-2*k-1))))
+		t.Quo(t, big.NewInt(int64(2*(k+1))))
+	}
+	r := BigIntn(total)
+	var chosen int
+	for k, t := range counts {
+		if r.Cmp(t) < 0 {
+			chosen = k
+			break
+		}
+		r.Sub(r, t)
+	}
+
+	p := Perm(n)
+	m := make([]int, n)
+	for i := range m {
+		m[i] = i
+	}
+	for k := 0; k < chosen; k++ {
+		a, b := p[2*k], p[2*k+1]
+		m[a], m[b] = b, a
+	}
+	return m
+}
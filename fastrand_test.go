@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"compress/gzip"
 	"crypto/rand"
+	"fmt"
 	"io"
 	"math"
 	"math/big"
@@ -254,6 +255,111 @@ func TestPerm(t *testing.T) {
 	}
 }
 
+// allPerms returns every permutation of the integers [0,n).
+func allPerms(n int) [][]int {
+	if n == 0 {
+		return [][]int{{}}
+	}
+	var perms [][]int
+	for _, p := range allPerms(n - 1) {
+		for i := 0; i < n; i++ {
+			q := append(append(append([]int(nil), p[:i]...), n-1), p[i:]...)
+			perms = append(perms, q)
+		}
+	}
+	return perms
+}
+
+// checkPermGenerator enumerates all permutations of [0,n), selects those
+// that satisfy valid, and checks that gen produces exactly those, each with
+// equal probability.
+func checkPermGenerator(t *testing.T, n int, valid func([]int) bool, gen func(int) []int) {
+	t.Helper()
+	index := make(map[string]int)
+	for _, p := range allPerms(n) {
+		if valid(p) {
+			index[fmt.Sprint(p)] = len(index)
+		}
+	}
+	probs := make([]float64, len(index))
+	for i := range probs {
+		probs[i] = 1 / float64(len(probs))
+	}
+	checkChiSquare(t, 200*len(index), probs, func() int {
+		p := gen(n)
+		i, ok := index[fmt.Sprint(p)]
+		if !ok {
+			t.Fatalf("generated invalid permutation %v", p)
+		}
+		return i
+	})
+}
+
+// TestCyclicPerm tests that CyclicPerm produces every cyclic permutation
+// with equal probability.
+func TestCyclicPerm(t *testing.T) {
+	isCyclic := func(p []int) bool {
+		i, steps := 0, 0
+		for {
+			i = p[i]
+			steps++
+			if i == 0 {
+				return steps == len(p)
+			}
+		}
+	}
+	// There are (5-1)! = 24 cyclic permutations of length 5.
+	checkPermGenerator(t, 5, isCyclic, CyclicPerm)
+	if p := CyclicPerm(1); len(p) != 1 || p[0] != 0 {
+		t.Errorf("unexpected cyclic permutation of length 1: %v", p)
+	}
+	if p := CyclicPerm(0); len(p) != 0 {
+		t.Errorf("unexpected cyclic permutation of length 0: %v", p)
+	}
+}
+
+// TestDerangement tests that Derangement produces every derangement with
+// equal probability.
+func TestDerangement(t *testing.T) {
+	if !panics(func() { Derangement(1) }) {
+		t.Error("expected panic for n == 1")
+	}
+	isDerangement := func(p []int) bool {
+		for i, j := range p {
+			if i == j {
+				return false
+			}
+		}
+		return true
+	}
+	// There are 44 derangements of length 5.
+	checkPermGenerator(t, 5, isDerangement, Derangement)
+	if p := Derangement(0); len(p) != 0 {
+		t.Errorf("unexpected derangement of length 0: %v", p)
+	}
+}
+
+// TestInvolution tests that Involution produces every involution with equal
+// probability.
+func TestInvolution(t *testing.T) {
+	isInvolution := func(p []int) bool {
+		for i, j := range p {
+			if p[j] != i {
+				return false
+			}
+		}
+		return true
+	}
+	// There are 26 involutions of length 5, and 76 of length 6.
+	checkPermGenerator(t, 5, isInvolution, Involution)
+	checkPermGenerator(t, 6, isInvolution, Involution)
+	for _, n := range []int{0, 1, 1000} {
+		if p := Involution(n); len(p) != n || !isInvolution(p) {
+			t.Errorf("invalid involution of length %v", n)
+		}
+	}
+}
+
 // BenchmarkUint64n benchmarks the Uint64n function for small uint64s.
 func BenchmarkUint64n(b *testing.B) {
 	for i := 0; i < b.N; i++ {
@@ -0,0 +1,112 @@
+package fastrand
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// fillChunk is the maximum number of bytes generated at a time by the Fill
+// functions.
+const fillChunk = 4096
+
+// wordStream yields uint64s decoded from bulk Read output. Words are always
+// decoded as little-endian, so that the values produced from a given stream
+// of bytes are the same on every host.
+type wordStream struct {
+	buf []byte
+	pos int
+}
+
+// newWordStream returns a wordStream that reads enough bytes at a time to
+// produce n words, up to a maximum of fillChunk bytes.
+func newWordStream(n int) *wordStream {
+	size := 8 * n
+	if size > fillChunk || size <= 0 {
+		size = fillChunk
+	}
+	return &wordStream{buf: make([]byte, size), pos: size}
+}
+
+// next returns the next word in the stream.
+func (s *wordStream) next() uint64 {
+	if s.pos == len(s.buf) {
+		Read(s.buf)
+		s.pos = 0
+	}
+	w := binary.LittleEndian.Uint64(s.buf[s.pos:])
+	s.pos += 8
+	return w
+}
+
+// FillUint64s fills dst with uniform random uint64s.
+func FillUint64s(dst []uint64) {
+	s := newWordStream(len(dst))
+	for i := range dst {
+		dst[i] = s.next()
+	}
+}
+
+// FillUint32s fills dst with uniform random uint32s.
+func FillUint32s(dst []uint32) {
+	s := newWordStream((len(dst) + 1) / 2)
+	for i := 0; i < len(dst); i += 2 {
+		w := s.next()
+		dst[i] = uint32(w)
+		if i+1 < len(dst) {
+			dst[i+1] = uint32(w >> 32)
+		}
+	}
+}
+
+// FillFloat64s fills dst with uniform random float64s in [0,1), as produced
+// by Float64.
+func FillFloat64s(dst []float64) {
+	s := newWordStream(len(dst))
+	for i := range dst {
+		dst[i] = float64(s.next()>>11) / (1 << 53)
+	}
+}
+
+// FillFloat32s fills dst with uniform random float32s in [0,1), as produced
+// by Float32.
+func FillFloat32s(dst []float32) {
+	s := newWordStream(len(dst))
+	for i := range dst {
+		dst[i] = float32(s.next()>>40) / (1 << 24)
+	}
+}
+
+// FillUint64n fills dst with uniform random uint64s in [0,n). It panics if
+// n == 0.
+func FillUint64n(dst []uint64, n uint64) {
+	if n == 0 {
+		panic("fastrand: argument to FillUint64n is 0")
+	}
+	s := newWordStream(len(dst))
+	for i := range dst {
+		dst[i] = s.uint64n(n)
+	}
+}
+
+// FillIntn fills dst with uniform random ints in [0,n). It panics if n <= 0.
+func FillIntn(dst []int, n int) {
+	if n <= 0 {
+		panic("fastrand: argument to FillIntn is <= 0: " + strconv.Itoa(n))
+	}
+	s := newWordStream(len(dst))
+	for i := range dst {
+		dst[i] = int(s.uint64n(uint64(n)))
+	}
+}
+
+// uint64n returns a uniform random uint64 in [0,n), using the same rejection
+// method as Uint64n.
+func (s *wordStream) uint64n(n uint64) uint64 {
+	max := math.MaxUint64 - math.MaxUint64%n
+	r := s.next()
+	for r >= max {
+		r = s.next()
+	}
+	return r % n
+}
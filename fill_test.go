@@ -0,0 +1,113 @@
+package fastrand
+
+import (
+	"testing"
+)
+
+// TestFillEndianness tests that the Fill functions decode Read output the
+// same way on every host.
+func TestFillEndianness(t *testing.T) {
+	u64 := make([]uint64, 2)
+	withReader(new(countingReader), func() { FillUint64s(u64) })
+	if u64[0] != 0x0706050403020100 || u64[1] != 0x0f0e0d0c0b0a0908 {
+		t.Errorf("unexpected FillUint64s output: %x", u64)
+	}
+
+	u32 := make([]uint32, 3)
+	withReader(new(countingReader), func() { FillUint32s(u32) })
+	if u32[0] != 0x03020100 || u32[1] != 0x07060504 || u32[2] != 0x0b0a0908 {
+		t.Errorf("unexpected FillUint32s output: %x", u32)
+	}
+
+	ints := make([]int, 2)
+	withReader(new(countingReader), func() { FillIntn(ints, 256) })
+	if ints[0] != 0x00 || ints[1] != 0x08 {
+		t.Errorf("unexpected FillIntn output: %x", ints)
+	}
+}
+
+// TestFillPanics tests that the bounded Fill functions panic on invalid
+// bounds.
+func TestFillPanics(t *testing.T) {
+	if !panics(func() { FillUint64n(make([]uint64, 1), 0) }) {
+		t.Error("expected panic for n == 0")
+	}
+	if !panics(func() { FillIntn(make([]int, 1), 0) }) {
+		t.Error("expected panic for n == 0")
+	}
+	if !panics(func() { FillIntn(make([]int, 1), -1) }) {
+		t.Error("expected panic for n < 0")
+	}
+	if panics(func() { FillIntn(nil, 1) }) {
+		t.Error("did not expect panic for empty dst")
+	}
+}
+
+// TestFillIntn tests that FillIntn produces uniform values, including when
+// dst is larger than a single chunk.
+func TestFillIntn(t *testing.T) {
+	const iters = 40000
+	dst := make([]int, iters)
+	FillIntn(dst, 10)
+	var counts [10]int
+	for _, r := range dst {
+		counts[r]++
+	}
+	exp := iters / len(counts)
+	lower, upper := exp-(exp/10), exp+(exp/10)
+	for i, n := range counts {
+		if !(lower < n && n < upper) {
+			t.Errorf("Expected range of %v-%v for index %v, got %v", lower, upper, i, n)
+		}
+	}
+
+	// A bound that triggers frequent rejection must still stay in range.
+	wide := make([]uint64, 1000)
+	FillUint64n(wide, 1<<63+1)
+	for _, r := range wide {
+		if r > 1<<63 {
+			t.Fatalf("FillUint64n returned %v", r)
+		}
+	}
+}
+
+// TestFillFloats tests that the float Fill functions produce values in [0,1)
+// with the expected mean.
+func TestFillFloats(t *testing.T) {
+	f64 := make([]float64, 10000)
+	FillFloat64s(f64)
+	f32 := make([]float32, 10000)
+	FillFloat32s(f32)
+	var sum64, sum32 float64
+	for i := range f64 {
+		if f64[i] < 0 || f64[i] >= 1 || f32[i] < 0 || f32[i] >= 1 {
+			t.Fatalf("got out of range values %v, %v", f64[i], f32[i])
+		}
+		sum64 += f64[i]
+		sum32 += float64(f32[i])
+	}
+	for _, sum := range []float64{sum64, sum32} {
+		if mean := sum / 10000; mean < 0.49 || mean > 0.51 {
+			t.Errorf("expected mean of 0.5, got %v", mean)
+		}
+	}
+}
+
+// BenchmarkFillIntn4k benchmarks FillIntn on a large slice.
+func BenchmarkFillIntn4k(b *testing.B) {
+	dst := make([]int, 4e3)
+	for i := 0; i < b.N; i++ {
+		FillIntn(dst, 4e3)
+	}
+}
+
+// BenchmarkIntnLoop4k benchmarks filling a large slice by calling Intn in a
+// loop, as a baseline for BenchmarkFillIntn4k.
+func BenchmarkIntnLoop4k(b *testing.B) {
+	dst := make([]int, 4e3)
+	for i := 0; i < b.N; i++ {
+		for j := range dst {
+			dst[j] = Intn(4e3)
+		}
+	}
+}
@@ -0,0 +1,127 @@
+package fastrand
+
+import (
+	"math"
+	"math/bits"
+)
+
+// Float64 returns a uniform random float64 in [0,1). The result is a multiple
+// of 2^-53, so every such multiple is equally likely.
+func Float64() float64 {
+	return float64(randUint64()>>11) / (1 << 53)
+}
+
+// Float64 returns a uniform random float64 in [0,1). See the package-level
+// Float64.
+func (g *Generator) Float64() float64 {
+	return float64(g.Uint64()>>11) / (1 << 53)
+}
+
+// Float32 returns a uniform random float32 in [0,1). The result is a multiple
+// of 2^-24, so every such multiple is equally likely.
+func Float32() float32 {
+	return float32(randUint64()>>40) / (1 << 24)
+}
+
+// Float64Full returns a uniform random float64 in [0,1), where every
+// representable float64 in the interval may be returned. The probability of
+// returning a value x is proportional to the width of the interval of reals
+// that round to x. In contrast to Float64, this means values smaller than
+// 2^-53 are reachable, and small values have their full 52 bits of precision.
+//
+// The method is due to Allen B. Downey, "Generating Pseudo-random Floating-
+// Point Values" (2007): the exponent is chosen by counting leading zero bits,
+// so that each binade is half as likely as the one above it, and the mantissa
+// is chosen uniformly.
+func Float64Full() float64 {
+	return (*Generator)(nil).Float64Full()
+}
+
+// Float64Full returns a uniform random float64 in [0,1), where every
+// representable float64 in the interval may be returned. See the package-level
+// Float64Full.
+func (g *Generator) Float64Full() float64 {
+	for {
+		mantissa, exp := g.Uint64()&(1<<52-1), g.fullExponent(1022)
+		// A zero mantissa lies on the boundary between two binades, and rounds
+		// up into the higher one half of the time.
+		if mantissa == 0 && g.Uint64()&1 == 1 {
+			exp++
+		}
+		if f := math.Float64frombits(exp<<52 | mantissa); f < 1 {
+			return f
+		}
+	}
+}
+
+// Float32Full returns a uniform random float32 in [0,1), where every
+// representable float32 in the interval may be returned. See Float64Full.
+func Float32Full() float32 {
+	return (*Generator)(nil).Float32Full()
+}
+
+// Float32Full returns a uniform random float32 in [0,1), where every
+// representable float32 in the interval may be returned. See the package-level
+// Float64Full.
+func (g *Generator) Float32Full() float32 {
+	for {
+		mantissa, exp := uint32(g.Uint64())&(1<<23-1), uint32(g.fullExponent(126))
+		if mantissa == 0 && g.Uint64()&1 == 1 {
+			exp++
+		}
+		if f := math.Float32frombits(exp<<23 | mantissa); f < 1 {
+			return f
+		}
+	}
+}
+
+// fullExponent returns a biased exponent in [0,max], where each exponent is
+// half as likely as the one above it. The smallest exponent, which denotes a
+// subnormal value, is as likely as the exponent above it.
+func (g *Generator) fullExponent(max uint64) uint64 {
+	exp := max
+	for exp > 0 {
+		w := g.Uint64()
+		if w != 0 {
+			zeros := uint64(bits.TrailingZeros64(w))
+			if zeros >= exp {
+				return 0
+			}
+			return exp - zeros
+		}
+		if exp < 64 {
+			return 0
+		}
+		exp -= 64
+	}
+	return 0
+}
+
+// FloatRange returns a uniform random float64 in [a,b). The result is never b,
+// even when a+(b-a)*Float64() would round up to b. It panics if a >= b or if
+// either argument is not finite.
+func FloatRange(a, b float64) float64 {
+	return (*Generator)(nil).FloatRange(a, b)
+}
+
+// FloatRange returns a uniform random float64 in [a,b). See the package-level
+// FloatRange.
+func (g *Generator) FloatRange(a, b float64) float64 {
+	if !(a < b) || math.IsInf(a, 0) || math.IsInf(b, 0) {
+		panic("fastrand: invalid range passed to FloatRange")
+	}
+	span := b - a
+	for {
+		u := g.Float64()
+		var x float64
+		if !math.IsInf(span, 0) {
+			x = a + span*u
+		} else {
+			// The width of the range overflows; work with halves instead.
+			x = 2 * (a/2 + (b/2-a/2)*u)
+		}
+		if a <= x && x < b {
+			return x
+		}
+	}
+}
@@ -0,0 +1,216 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// TestFloat64 tests that Float64 produces multiples of 2^-53 in [0,1) with
+// the expected mean.
+func TestFloat64(t *testing.T) {
+	const iters = 10000
+	var sum float64
+	for i := 0; i < iters; i++ {
+		f := Float64()
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float64 returned %v", f)
+		}
+		if f*(1<<53) != math.Floor(f*(1<<53)) {
+			t.Fatalf("Float64 returned %v, which is not a multiple of 2^-53", f)
+		}
+		sum += f
+	}
+	if mean := sum / iters; mean < 0.49 || mean > 0.51 {
+		t.Errorf("expected mean of 0.5, got %v", mean)
+	}
+}
+
+// TestFloat32 tests that Float32 produces multiples of 2^-24 in [0,1) with
+// the expected mean.
+func TestFloat32(t *testing.T) {
+	const iters = 10000
+	var sum float64
+	for i := 0; i < iters; i++ {
+		f := Float32()
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float32 returned %v", f)
+		}
+		if f*(1<<24) != float32(math.Floor(float64(f)*(1<<24))) {
+			t.Fatalf("Float32 returned %v, which is not a multiple of 2^-24", f)
+		}
+		sum += float64(f)
+	}
+	if mean := sum / iters; mean < 0.49 || mean > 0.51 {
+		t.Errorf("expected mean of 0.5, got %v", mean)
+	}
+}
+
+// TestFloat64Full tests that Float64Full produces values in [0,1) with the
+// expected distribution, and that small values have full precision.
+func TestFloat64Full(t *testing.T) {
+	const iters = 20000
+	var sum float64
+	var small, smallFine int
+	for i := 0; i < iters; i++ {
+		f := Float64Full()
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float64Full returned %v", f)
+		}
+		sum += f
+		// In [1/8,1/4), Float64 can only produce values whose low 3 mantissa
+		// bits are zero. Float64Full should produce the others as well.
+		if 0.125 <= f && f < 0.25 {
+			small++
+			if math.Float64bits(f)&7 != 0 {
+				smallFine++
+			}
+		}
+	}
+	if mean := sum / iters; mean < 0.49 || mean > 0.51 {
+		t.Errorf("expected mean of 0.5, got %v", mean)
+	}
+	if small < iters/8*9/10 || small > iters/8*11/10 {
+		t.Errorf("expected roughly %v values in [1/8,1/4), got %v", iters/8, small)
+	}
+	if smallFine < small*3/4 {
+		t.Errorf("only %v of %v values in [1/8,1/4) used their full precision", smallFine, small)
+	}
+}
+
+// TestFloat32Full tests that Float32Full produces values in [0,1) with the
+// expected distribution, and that small values have full precision.
+func TestFloat32Full(t *testing.T) {
+	const iters = 20000
+	var sum float64
+	var small, smallFine int
+	for i := 0; i < iters; i++ {
+		f := Float32Full()
+		if f < 0 || f >= 1 {
+			t.Fatalf("Float32Full returned %v", f)
+		}
+		sum += float64(f)
+		if 0.125 <= f && f < 0.25 {
+			small++
+			if math.Float32bits(f)&7 != 0 {
+				smallFine++
+			}
+		}
+	}
+	if mean := sum / iters; mean < 0.49 || mean > 0.51 {
+		t.Errorf("expected mean of 0.5, got %v", mean)
+	}
+	if smallFine < small*3/4 {
+		t.Errorf("only %v of %v values in [1/8,1/4) used their full precision", smallFine, small)
+	}
+}
+
+// TestGeneratorFloat tests that the Generator methods Float64, Float64Full
+// and Float32Full draw from the Generator's source.
+func TestGeneratorFloat(t *testing.T) {
+	a, b := NewSeededGenerator([]byte("seed")), NewSeededGenerator([]byte("seed"))
+	for i := 0; i < 100; i++ {
+		if f, g := a.Float64(), b.Float64(); f != g || f < 0 || f >= 1 {
+			t.Fatalf("seeded Generators returned %v and %v from Float64", f, g)
+		}
+		f, g := a.Float64Full(), b.Float64Full()
+		if f != g || f < 0 || f >= 1 {
+			t.Fatalf("seeded Generators returned %v and %v from Float64Full", f, g)
+		}
+		f32, g32 := a.Float32Full(), b.Float32Full()
+		if f32 != g32 || f32 < 0 || f32 >= 1 {
+			t.Fatalf("seeded Generators returned %v and %v from Float32Full", f32, g32)
+		}
+	}
+}
+
+// TestFullExponent tests that fullExponent never leaves [0,max], and that
+// exponents at the bottom of a short range are reachable.
+func TestFullExponent(t *testing.T) {
+	var counts [3]int
+	for i := 0; i < 10000; i++ {
+		counts[(*Generator)(nil).fullExponent(2)]++
+	}
+	// Expected probabilities are 1/2, 1/4 and 1/4 for 2, 1 and 0.
+	if counts[2] < 4500 || counts[2] > 5500 {
+		t.Errorf("expected roughly 5000 draws of exponent 2, got %v", counts[2])
+	}
+	for _, e := range counts[:2] {
+		if e < 2250 || e > 2750 {
+			t.Errorf("expected roughly 2500 draws of exponents 0 and 1, got %v", counts[:2])
+		}
+	}
+	for i := 0; i < 1000; i++ {
+		if e := (*Generator)(nil).fullExponent(1022); e > 1022 {
+			t.Fatalf("fullExponent returned %v", e)
+		}
+	}
+}
+
+// TestFloatRangePanics tests that FloatRange panics on invalid ranges.
+func TestFloatRangePanics(t *testing.T) {
+	for _, r := range [][2]float64{
+		{1, 1},
+		{1, 0},
+		{math.NaN(), 1},
+		{0, math.NaN()},
+		{math.Inf(-1), 0},
+		{0, math.Inf(1)},
+	} {
+		if !panics(func() { FloatRange(r[0], r[1]) }) {
+			t.Errorf("expected panic for range %v", r)
+		}
+	}
+	if panics(func() { FloatRange(-1, 1) }) {
+		t.Error("did not expect panic for valid range")
+	}
+}
+
+// TestFloatRange tests that FloatRange never returns its upper bound, even
+// when rounding would otherwise produce it.
+func TestFloatRange(t *testing.T) {
+	tests := [][2]float64{
+		{0, 1},
+		{-1, 1},
+		{1, math.Nextafter(1, 2)},
+		{0, math.SmallestNonzeroFloat64},
+		{1e16, 1e16 + 2},
+		{-math.MaxFloat64, math.MaxFloat64},
+		{math.Nextafter(math.MaxFloat64, 0), math.MaxFloat64},
+	}
+	g := NewGenerator(Reader)
+	for _, r := range tests {
+		for i := 0; i < 1000; i++ {
+			if x := FloatRange(r[0], r[1]); x < r[0] || x >= r[1] {
+				t.Fatalf("FloatRange(%v, %v) returned %v", r[0], r[1], x)
+			}
+			if x := g.FloatRange(r[0], r[1]); x < r[0] || x >= r[1] {
+				t.Fatalf("Generator.FloatRange(%v, %v) returned %v", r[0], r[1], x)
+			}
+		}
+	}
+
+	// The full range should produce negative and positive values equally.
+	var neg int
+	for i := 0; i < 10000; i++ {
+		if FloatRange(-math.MaxFloat64, math.MaxFloat64) < 0 {
+			neg++
+		}
+	}
+	if neg < 4500 || neg > 5500 {
+		t.Errorf("expected roughly 5000 negative values, got %v", neg)
+	}
+}
+
+// BenchmarkFloat64 benchmarks the Float64 function.
+func BenchmarkFloat64(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Float64()
+	}
+}
+
+// BenchmarkFloat64Full benchmarks the Float64Full function.
+func BenchmarkFloat64Full(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Float64Full()
+	}
+}
@@ -0,0 +1,112 @@
+package fastrand
+
+import (
+	"bytes"
+	"testing"
+)
+
+// countingReader is an io.Reader that produces the bytes 0, 1, 2, ...,
+// wrapping around after 255.
+type countingReader struct {
+	next byte
+}
+
+func (r *countingReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = r.next
+		r.next++
+	}
+	return len(b), nil
+}
+
+// withReader runs fn with Reader replaced by r.
+func withReader(r *countingReader, fn func()) {
+	old := Reader
+	Reader = r
+	defer func() { Reader = old }()
+	fn()
+}
+
+// TestGeneratorNil tests that a nil Generator draws from Reader.
+func TestGeneratorNil(t *testing.T) {
+	var g *Generator
+	withReader(new(countingReader), func() {
+		if x := g.Uint64(); x != 0x0706050403020100 {
+			t.Errorf("unexpected Uint64 from nil Generator: %x", x)
+		}
+	})
+	if panics(func() { g.Intn(10) }) {
+		t.Error("did not expect panic from nil Generator")
+	}
+}
+
+// TestGeneratorSource tests that a Generator draws from its source, and
+// panics when the source is exhausted.
+func TestGeneratorSource(t *testing.T) {
+	g := NewGenerator(new(countingReader))
+	if x := g.Uint64(); x != 0x0706050403020100 {
+		t.Errorf("unexpected Uint64: %x", x)
+	}
+	if x := g.Uint64n(256); x != 0x08 {
+		t.Errorf("unexpected Uint64n: %x", x)
+	}
+
+	short := NewGenerator(bytes.NewReader(make([]byte, 4)))
+	if !panics(func() { short.Uint64() }) {
+		t.Error("expected panic for exhausted source")
+	}
+}
+
+// TestSeededGenerator tests that seeded Generators are deterministic, and
+// that different seeds give different output.
+func TestSeededGenerator(t *testing.T) {
+	a, b := NewSeededGenerator([]byte("foo")), NewSeededGenerator([]byte("foo"))
+	c := NewSeededGenerator([]byte("bar"))
+	for i := 0; i < 100; i++ {
+		x, y, z := a.Uint64(), b.Uint64(), c.Uint64()
+		if x != y {
+			t.Fatalf("same seed produced %x and %x", x, y)
+		}
+		if x == z {
+			t.Fatalf("different seeds both produced %x", x)
+		}
+	}
+	// Large reads span several hashes.
+	bufA, bufB := make([]byte, 1000), make([]byte, 1000)
+	a.Read(bufA)
+	b.Read(bufB)
+	if !bytes.Equal(bufA, bufB) {
+		t.Error("same seed produced different reads")
+	}
+}
+
+// TestGeneratorPanics tests that the bounded Generator methods panic on
+// invalid bounds.
+func TestGeneratorPanics(t *testing.T) {
+	g := NewGenerator(Reader)
+	if !panics(func() { g.Uint64n(0) }) {
+		t.Error("expected panic for n == 0")
+	}
+	if !panics(func() { g.Intn(0) }) {
+		t.Error("expected panic for n == 0")
+	}
+}
+
+// TestGeneratorIntn tests that Generator's Intn method is uniform, for both
+// fresh and seeded Generators.
+func TestGeneratorIntn(t *testing.T) {
+	for _, g := range []*Generator{NewGenerator(Reader), NewSeededGenerator([]byte("foo"))} {
+		const iters = 10000
+		var counts [10]int
+		for i := 0; i < iters; i++ {
+			counts[g.Intn(len(counts))]++
+		}
+		exp := iters / len(counts)
+		lower, upper := exp-(exp/10), exp+(exp/10)
+		for i, n := range counts {
+			if !(lower < n && n < upper) {
+				t.Errorf("Expected range of %v-%v for index %v, got %v", lower, upper, i, n)
+			}
+		}
+	}
+}
@@ -0,0 +1,52 @@
+package lattice
+
+import (
+	"encoding/binary"
+	"math/bits"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// CenteredBinomial is the centered binomial distribution with parameter Eta:
+// the number of ones among Eta random bits, minus the number of ones among
+// Eta more. Its values lie in [-Eta,Eta], and its variance is Eta/2. It is
+// the error distribution of Kyber, with Eta = 2 or 3.
+type CenteredBinomial struct {
+	Eta int
+	Src *fastrand.Generator
+}
+
+// Rand returns a random value drawn from the distribution. It consumes 8
+// bytes from Src. It panics if Eta is not in [1,32].
+func (c CenteredBinomial) Rand() int32 {
+	c.check()
+	return c.sample(c.Src.Uint64())
+}
+
+// Fill fills dst with random values drawn from the distribution, reading all
+// of the randomness it needs from Src at once. It panics if Eta is not in
+// [1,32].
+func (c CenteredBinomial) Fill(dst []int32) {
+	c.check()
+	buf := make([]byte, 8*len(dst))
+	c.Src.Read(buf)
+	for i := range dst {
+		dst[i] = c.sample(binary.LittleEndian.Uint64(buf[8*i:]))
+	}
+}
+
+// check panics if Eta is out of range.
+func (c CenteredBinomial) check() {
+	if c.Eta < 1 || c.Eta > 32 {
+		panic("fastrand: CenteredBinomial parameter is not in [1,32]")
+	}
+}
+
+// sample maps a random word to a value of the distribution, using the low
+// 2*Eta bits of w.
+func (c CenteredBinomial) sample(w uint64) int32 {
+	mask := uint64(1)<<uint(c.Eta) - 1
+	a := bits.OnesCount64(w & mask)
+	b := bits.OnesCount64((w >> uint(c.Eta)) & mask)
+	return int32(a - b)
+}
@@ -0,0 +1,86 @@
+package lattice
+
+import (
+	"bytes"
+	"math"
+	"os"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// centeredBinomialProbs returns the probabilities of the values -eta, ...,
+// eta under the centered binomial distribution with parameter eta.
+func centeredBinomialProbs(eta int) []float64 {
+	// The difference of the two sums is distributed as Binomial(2*eta, 1/2)
+	// shifted by -eta.
+	probs := make([]float64, 2*eta+1)
+	c := 1.0
+	for k := range probs {
+		probs[k] = c * math.Pow(2, -2*float64(eta))
+		c = c * float64(2*eta-k) / float64(k+1)
+	}
+	return probs
+}
+
+// TestCenteredBinomial tests CenteredBinomial's distribution and variance.
+func TestCenteredBinomial(t *testing.T) {
+	for _, eta := range []int{1, 2, 3, 8, 32} {
+		c := CenteredBinomial{Eta: eta}
+		checkDistribution(t, "CenteredBinomial", centeredBinomialProbs(eta), c.Rand, 40000)
+	}
+}
+
+// TestCenteredBinomialFill tests that Fill draws the same values as
+// repeated calls to Rand.
+func TestCenteredBinomialFill(t *testing.T) {
+	seed := fastrand.Bytes(8 * 100)
+	a := CenteredBinomial{Eta: 3, Src: fastrand.NewGenerator(bytes.NewReader(seed))}
+	b := CenteredBinomial{Eta: 3, Src: fastrand.NewGenerator(bytes.NewReader(seed))}
+	dst := make([]int32, 100)
+	a.Fill(dst)
+	for i, x := range dst {
+		if y := b.Rand(); x != y {
+			t.Fatalf("Fill and Rand disagree at %v: %v != %v", i, x, y)
+		}
+	}
+}
+
+// TestCenteredBinomialPanics tests that CenteredBinomial panics for
+// parameters outside [1,32].
+func TestCenteredBinomialPanics(t *testing.T) {
+	for _, eta := range []int{-1, 0, 33} {
+		if !panics(func() { CenteredBinomial{Eta: eta}.Rand() }) {
+			t.Errorf("expected panic for Eta = %v", eta)
+		}
+		if !panics(func() { CenteredBinomial{Eta: eta}.Fill(make([]int32, 1)) }) {
+			t.Errorf("expected panic for Eta = %v", eta)
+		}
+	}
+}
+
+// TestCenteredBinomialTiming checks that the running time of CenteredBinomial
+// does not depend on the random bits it consumes.
+func TestCenteredBinomialTiming(t *testing.T) {
+	if os.Getenv("FASTRAND_TIMING") == "" {
+		t.Skip("set FASTRAND_TIMING=1 to run timing tests")
+	}
+	// These sources produce the values 0 and -2 respectively.
+	c := [2]CenteredBinomial{
+		{Eta: 3, Src: fastrand.NewGenerator(constReader(0x00))},
+		{Eta: 3, Src: fastrand.NewGenerator(constReader(0x30))},
+	}
+	tc := timingLeak(20000, 16, func(class int) { c[class].Rand() })
+	if math.Abs(tc) > 10 {
+		t.Errorf("CenteredBinomial timing depends on its randomness: t = %.2f", tc)
+	}
+}
+
+// BenchmarkCenteredBinomialFill benchmarks filling a Kyber-sized vector.
+func BenchmarkCenteredBinomialFill(b *testing.B) {
+	c := CenteredBinomial{Eta: 2}
+	dst := make([]int32, 256)
+	for i := 0; i < b.N; i++ {
+		c.Fill(dst)
+	}
+}
@@ -0,0 +1,129 @@
+package lattice
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// cdtPrec is the precision, in bits, of the arithmetic used to build a CDT.
+const cdtPrec = 256
+
+// A CDTGaussian samples the discrete Gaussian distribution over the
+// integers, with P(x) proportional to exp(-x^2/(2*sigma^2)), by inversion of
+// a cumulative distribution table. It is safe for concurrent use if Src is.
+//
+// The table holds the cumulative distribution of |x| with 63 bits of
+// precision, so each probability is within 2^-63 of its true value, and it
+// extends until the remaining tail probability is below 2^-63. Each draw
+// compares a random 63-bit value against every entry of the table, so the
+// running time depends only on the table's length.
+type CDTGaussian struct {
+	table []uint64
+
+	// Src is the Generator that Rand and Fill draw from. A nil Src draws
+	// from fastrand.Reader.
+	Src *fastrand.Generator
+}
+
+// NewCDTGaussian returns a CDTGaussian with standard deviation parameter
+// sigma and a nil Src. It panics if sigma is not in (0, 1024].
+func NewCDTGaussian(sigma float64) *CDTGaussian {
+	if !(sigma > 0) || sigma > 1<<10 {
+		panic("fastrand: CDTGaussian sigma is not in (0, 1024]")
+	}
+	// The table covers |x| up to the point where exp(-x^2/(2*sigma^2)) is
+	// below 2^-70, well past where the tail probability drops below 2^-63.
+	n := int(math.Ceil(sigma*math.Sqrt(2*70*math.Ln2))) + 1
+
+	// weights[k] is the unnormalized probability of |x| = k; nonzero values
+	// count twice, for x and -x.
+	twoSigma2 := new(big.Float).SetPrec(cdtPrec).SetFloat64(sigma)
+	twoSigma2.Mul(twoSigma2, twoSigma2)
+	twoSigma2.Add(twoSigma2, twoSigma2)
+	weights := make([]*big.Float, n)
+	total := new(big.Float).SetPrec(cdtPrec)
+	for k := range weights {
+		x := new(big.Float).SetPrec(cdtPrec).SetInt64(int64(k) * int64(k))
+		weights[k] = expNeg(x.Quo(x, twoSigma2))
+		if k > 0 {
+			weights[k].Add(weights[k], weights[k])
+		}
+		total.Add(total, weights[k])
+	}
+
+	// table[k] = floor(2^63 * P(|x| <= k)). Entries that have reached 2^63
+	// can never be exceeded, so they are dropped.
+	scale := new(big.Float).SetPrec(cdtPrec).SetMantExp(big.NewFloat(1), 63)
+	scale.Quo(scale, total)
+	g := new(CDTGaussian)
+	cum := new(big.Float).SetPrec(cdtPrec)
+	for _, w := range weights {
+		cum.Add(cum, w)
+		v, _ := new(big.Float).Mul(cum, scale).Uint64()
+		if v >= 1<<63 {
+			break
+		}
+		g.table = append(g.table, v)
+	}
+	return g
+}
+
+// Rand returns a random value drawn from the distribution. It consumes 8
+// bytes from Src.
+func (g *CDTGaussian) Rand() int32 {
+	return g.sample(g.Src.Uint64())
+}
+
+// Fill fills dst with random values drawn from the distribution, reading all
+// of the randomness it needs from Src at once.
+func (g *CDTGaussian) Fill(dst []int32) {
+	buf := make([]byte, 8*len(dst))
+	g.Src.Read(buf)
+	for i := range dst {
+		dst[i] = g.sample(binary.LittleEndian.Uint64(buf[8*i:]))
+	}
+}
+
+// sample maps a random word to a value of the distribution. The top 63 bits
+// of w select |x| by inversion, and the low bit selects its sign.
+func (g *CDTGaussian) sample(w uint64) int32 {
+	r := w >> 1
+	var z int32
+	for _, t := range g.table {
+		// Both r and t are below 2^63, so t-r-1 underflows, setting the top
+		// bit, exactly when r >= t.
+		z += int32((t - r - 1) >> 63)
+	}
+	return ctNegate(z, w&1)
+}
+
+// Len returns the number of entries in the table, which determines the
+// running time of each draw.
+func (g *CDTGaussian) Len() int {
+	return len(g.table)
+}
+
+// expNeg returns exp(-x) for x >= 0, computed to the precision of x. The
+// argument is halved until it is small, the Taylor series is summed, and the
+// result is squared back up.
+func expNeg(x *big.Float) *big.Float {
+	prec := x.Prec()
+	const halvings = 16
+	y := new(big.Float).SetPrec(prec+halvings).SetMantExp(x, -halvings)
+	y.Neg(y)
+	sum := new(big.Float).SetPrec(prec + halvings).SetInt64(1)
+	term := new(big.Float).SetPrec(prec + halvings).SetInt64(1)
+	eps := new(big.Float).SetMantExp(big.NewFloat(1), -int(prec+halvings))
+	for i := int64(1); term.Sign() != 0 && new(big.Float).Abs(term).Cmp(eps) > 0; i++ {
+		term.Mul(term, y)
+		term.Quo(term, new(big.Float).SetInt64(i))
+		sum.Add(sum, term)
+	}
+	for i := 0; i < halvings; i++ {
+		sum.Mul(sum, sum)
+	}
+	return sum.SetPrec(prec)
+}
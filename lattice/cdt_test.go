@@ -0,0 +1,149 @@
+package lattice
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"os"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// gaussianProbs returns the probabilities of the values -m, ..., m under the
+// discrete Gaussian with parameter sigma, where m covers all but a negligible
+// tail.
+func gaussianProbs(sigma float64) []float64 {
+	m := int(math.Ceil(12 * sigma))
+	probs := make([]float64, 2*m+1)
+	var sum float64
+	for i := range probs {
+		x := float64(i - m)
+		probs[i] = math.Exp(-x * x / (2 * sigma * sigma))
+		sum += probs[i]
+	}
+	for i := range probs {
+		probs[i] /= sum
+	}
+	return probs
+}
+
+// TestCDTGaussian tests CDTGaussian's distribution and variance.
+func TestCDTGaussian(t *testing.T) {
+	for _, sigma := range []float64{0.5, 1, 1.7, 3.2, 10} {
+		g := NewCDTGaussian(sigma)
+		checkDistribution(t, "CDTGaussian", gaussianProbs(sigma), g.Rand, 40000)
+	}
+}
+
+// TestCDTGaussianTable tests that the table is increasing, and that it
+// matches the discrete Gaussian's CDF to within the table's precision.
+func TestCDTGaussianTable(t *testing.T) {
+	const sigma = 3.2
+	g := NewCDTGaussian(sigma)
+	probs := gaussianProbs(sigma)
+	m := len(probs) / 2
+	cum := probs[m]
+	for k, v := range g.table {
+		if k > 0 && v < g.table[k-1] {
+			t.Fatalf("table decreases at %v", k)
+		}
+		if k > 0 {
+			cum += 2 * probs[m+k]
+		}
+		if d := math.Abs(float64(v)/(1<<63) - cum); d > 1e-14 {
+			t.Errorf("table[%v] = %v, expected CDF %v", k, float64(v)/(1<<63), cum)
+		}
+	}
+	// The table should extend until the tail is below 2^-63, which for
+	// sigma = 3.2 is about 10 standard deviations.
+	if g.Len() < 30 || g.Len() > 35 {
+		t.Errorf("unexpected table length %v", g.Len())
+	}
+}
+
+// TestCDTGaussianFill tests that Fill draws the same values as repeated
+// calls to Rand.
+func TestCDTGaussianFill(t *testing.T) {
+	seed := fastrand.Bytes(8 * 100)
+	a, b := NewCDTGaussian(2), NewCDTGaussian(2)
+	a.Src = fastrand.NewGenerator(bytes.NewReader(seed))
+	b.Src = fastrand.NewGenerator(bytes.NewReader(seed))
+	dst := make([]int32, 100)
+	a.Fill(dst)
+	for i, x := range dst {
+		if y := b.Rand(); x != y {
+			t.Fatalf("Fill and Rand disagree at %v: %v != %v", i, x, y)
+		}
+	}
+}
+
+// TestCDTGaussianPanics tests that NewCDTGaussian panics for invalid sigma.
+func TestCDTGaussianPanics(t *testing.T) {
+	for _, sigma := range []float64{0, -1, math.NaN(), 2000} {
+		if !panics(func() { NewCDTGaussian(sigma) }) {
+			t.Errorf("expected panic for sigma = %v", sigma)
+		}
+	}
+}
+
+// TestExpNeg tests expNeg against math.Exp.
+func TestExpNeg(t *testing.T) {
+	for _, x := range []float64{0, 1e-10, 0.5, 1, 7.25, 48.5} {
+		got, _ := expNeg(new(big.Float).SetPrec(cdtPrec).SetFloat64(x)).Float64()
+		if want := math.Exp(-x); math.Abs(got-want) > 4e-16*want {
+			t.Errorf("expNeg(%v) = %v, expected %v", x, got, want)
+		}
+	}
+}
+
+// variableTimeSample is a CDT sampler that stops scanning the table at the
+// first entry above r. It is used to check that the timing harness can
+// detect a leak.
+func variableTimeSample(table []uint64, w uint64) int32 {
+	r := w >> 1
+	var z int32
+	for _, t := range table {
+		if r < t {
+			break
+		}
+		z++
+	}
+	if w&1 == 1 {
+		return -z
+	}
+	return z
+}
+
+// TestCDTGaussianTiming checks that the running time of CDTGaussian does not
+// depend on the random bits it consumes, and that the same harness detects
+// the data-dependent running time of an early-exit table scan.
+func TestCDTGaussianTiming(t *testing.T) {
+	if os.Getenv("FASTRAND_TIMING") == "" {
+		t.Skip("set FASTRAND_TIMING=1 to run timing tests")
+	}
+	// These sources produce the smallest and largest values in the table.
+	g := [2]*CDTGaussian{NewCDTGaussian(3.2), NewCDTGaussian(3.2)}
+	g[0].Src = fastrand.NewGenerator(constReader(0x00))
+	g[1].Src = fastrand.NewGenerator(constReader(0xff))
+	const samples, batch = 20000, 16
+	tc := timingLeak(samples, batch, func(class int) { g[class].Rand() })
+	if math.Abs(tc) > 10 {
+		t.Errorf("CDTGaussian timing depends on its randomness: t = %.2f", tc)
+	}
+	tv := timingLeak(samples, batch, func(class int) {
+		variableTimeSample(g[class].table, g[class].Src.Uint64())
+	})
+	if math.Abs(tv) < 10 {
+		t.Errorf("harness failed to detect early-exit timing leak: t = %.2f", tv)
+	}
+}
+
+// BenchmarkCDTGaussianFill benchmarks filling a 512-entry vector.
+func BenchmarkCDTGaussianFill(b *testing.B) {
+	g := NewCDTGaussian(1.7)
+	dst := make([]int32, 512)
+	for i := 0; i < b.N; i++ {
+		g.Fill(dst)
+	}
+}
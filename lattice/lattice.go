@@ -0,0 +1,21 @@
+// Package lattice provides samplers for the small error distributions used in
+// lattice-based cryptography.
+//
+// Every sampler runs in constant time: the sequence of instructions and
+// memory accesses it performs depends only on its parameters, never on the
+// random bits it consumes or the values it returns. Secret-dependent branches
+// and table lookups are replaced by arithmetic on masks. Go makes no formal
+// guarantee that the compiler preserves this property, so the tests measure
+// it empirically.
+//
+// Samplers draw from the *fastrand.Generator in their Src field. A nil Src
+// draws from
+// fastrand.Reader; a Generator built on an expandable output function lets a
+// scheme derive its error terms deterministically from a seed.
+package lattice
+
+// ctNegate returns -x if neg is 1, and x if neg is 0, without branching.
+func ctNegate(x int32, neg uint64) int32 {
+	mask := -int32(neg)
+	return (x ^ mask) - mask
+}
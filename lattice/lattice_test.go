@@ -0,0 +1,161 @@
+package lattice
+
+import (
+	"math"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// constReader is a source of random bytes that only ever returns its own
+// value. Generators built on constReaders force a sampler down its extreme
+// paths, for timing tests.
+type constReader byte
+
+// Read fills b with c.
+func (c constReader) Read(b []byte) (int, error) {
+	for i := range b {
+		b[i] = byte(c)
+	}
+	return len(b), nil
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkDistribution draws iters values from rand, and fails the test if a
+// chi-square test rejects the probabilities probs of the values -m, ..., m,
+// or if the sample variance is inconsistent with the variance of probs.
+// The tails, whose expected counts are too small to test individually, are
+// folded into the outermost bins that are large enough.
+func checkDistribution(t *testing.T, name string, probs []float64, rand func() int32, iters int) {
+	t.Helper()
+	m := int32(len(probs) / 2)
+	var variance, fourth float64
+	for i, p := range probs {
+		x := float64(int32(i) - m)
+		variance += p * x * x
+		fourth += p * x * x * x * x
+	}
+
+	counts := make([]int, len(probs))
+	var sumSq float64
+	for i := 0; i < iters; i++ {
+		x := rand()
+		if x < -m || x > m || probs[x+m] == 0 {
+			t.Fatalf("%v: drew impossible value %v", name, x)
+		}
+		counts[x+m]++
+		sumSq += float64(x) * float64(x)
+	}
+
+	lo, hi := 0, len(probs)-1
+	for lo < hi && probs[lo]*float64(iters) < 10 {
+		lo++
+	}
+	for hi > lo && probs[hi]*float64(iters) < 10 {
+		hi--
+	}
+	binP := append([]float64(nil), probs[lo:hi+1]...)
+	binCounts := append([]int(nil), counts[lo:hi+1]...)
+	for i := range probs {
+		j := 0
+		if i > hi {
+			j = hi - lo
+		} else if i >= lo {
+			continue
+		}
+		binP[j] += probs[i]
+		binCounts[j] += counts[i]
+	}
+	var stat float64
+	for i, p := range binP {
+		e := p * float64(iters)
+		stat += (float64(binCounts[i]) - e) * (float64(binCounts[i]) - e) / e
+	}
+	df := len(binP) - 1
+	if df < 1 {
+		df = 1
+	}
+	if crit := chiSquareCritical(df); stat > crit {
+		t.Errorf("%v: chi-square statistic %.2f exceeds critical value %.2f; counts %v", name, stat, crit, counts)
+	}
+
+	// The mean is zero, so the sample variance is the mean of the squares,
+	// which has standard deviation sqrt((E[x^4] - variance^2) / iters).
+	sd := math.Sqrt((fourth - variance*variance) / float64(iters))
+	if v := sumSq / float64(iters); math.Abs(v-variance) > 5*sd {
+		t.Errorf("%v: sample variance %v, expected %v", name, v, variance)
+	}
+}
+
+// timingLeak measures fn over two classes of inputs, dudect-style, and
+// returns Welch's t-statistic for the difference in their execution times.
+// For each measurement, a class is chosen at random and fn is called with it
+// batch times. The slowest measurements are cropped before computing the
+// statistic, as they are dominated by scheduling noise. Tests that use it
+// only run when FASTRAND_TIMING is set, since a loaded machine can skew
+// wall-clock measurements enough to fail them.
+func timingLeak(samples, batch int, fn func(class int)) float64 {
+	classes := make([]int, samples)
+	times := make([]float64, samples)
+	for i := range classes {
+		classes[i] = fastrand.Intn(2)
+	}
+	for i, c := range classes {
+		start := time.Now()
+		for j := 0; j < batch; j++ {
+			fn(c)
+		}
+		times[i] = float64(time.Since(start))
+	}
+
+	// Crop everything above the 90th percentile.
+	sorted := append([]float64(nil), times...)
+	sort.Float64s(sorted)
+	cutoff := sorted[len(sorted)*9/10]
+
+	var n, mean, m2 [2]float64
+	for i, t := range times {
+		if t > cutoff {
+			continue
+		}
+		c := classes[i]
+		n[c]++
+		delta := t - mean[c]
+		mean[c] += delta / n[c]
+		m2[c] += delta * (t - mean[c])
+	}
+	v0, v1 := m2[0]/(n[0]-1), m2[1]/(n[1]-1)
+	return (mean[0] - mean[1]) / math.Sqrt(v0/n[0]+v1/n[1])
+}
+
+// TestCtNegate tests the ctNegate function.
+func TestCtNegate(t *testing.T) {
+	for _, x := range []int32{0, 1, -1, 12345, math.MaxInt32, math.MinInt32 + 1} {
+		if y := ctNegate(x, 0); y != x {
+			t.Errorf("ctNegate(%v, 0) = %v", x, y)
+		}
+		if y := ctNegate(x, 1); y != -x {
+			t.Errorf("ctNegate(%v, 1) = %v", x, y)
+		}
+	}
+}
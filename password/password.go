@@ -0,0 +1,316 @@
+// Package password generates random passwords that satisfy composition
+// policies, such as "16 to 24 characters, with at least one uppercase
+// letter, one digit and one symbol".
+//
+// Passwords are drawn uniformly from the set of all passwords that satisfy
+// the policy. A common alternative, generating a password and then replacing
+// characters until it complies, is biased: it favors passwords with exactly
+// one character of each required class, and often places them at
+// predictable positions. Instead, Policy counts the compliant passwords of
+// each length and composition exactly, and samples among them in proportion
+// to their number, so Entropy reports the true strength of the result.
+package password
+
+import (
+	"math"
+	"math/big"
+	"strings"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Character classes for use in a Policy.
+const (
+	Lower   = "abcdefghijklmnopqrstuvwxyz"
+	Upper   = "ABCDEFGHIJKLMNOPQRSTUVWXYZ"
+	Digits  = "0123456789"
+	Symbols = "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~"
+)
+
+// A Class is a set of characters that a password may contain, with the
+// minimum number of them that it must contain.
+type Class struct {
+	Chars string
+	Min   int
+}
+
+// A Policy describes a set of acceptable passwords: those whose length is in
+// [MinLen,MaxLen], whose characters are drawn from the union of Classes, and
+// which contain at least Min characters of each Class. If NoRepeats is set,
+// no character may appear in a password more than once.
+//
+// The classes must be disjoint, and each must consist of distinct ASCII
+// characters.
+type Policy struct {
+	Classes   []Class
+	MinLen    int
+	MaxLen    int
+	NoRepeats bool
+
+	// Src is the generator used by New. A nil Src draws from
+	// fastrand.Reader.
+	Src *fastrand.Generator
+}
+
+// New returns a password drawn uniformly from the passwords allowed by p. It
+// panics if p is invalid, or if it allows no passwords.
+//
+// The length is chosen in proportion to the number of allowed passwords of
+// each length, and then the number of characters from each class in
+// proportion to the number of allowed passwords with those counts. The
+// classes are arranged in a uniformly random order, and the characters of
+// each class are drawn uniformly, without replacement if NoRepeats is set.
+func (p Policy) New() string {
+	ways := p.ways()
+	k := len(p.Classes)
+	if total := p.total(ways); total.Sign() == 0 {
+		panic("fastrand: password policy allows no passwords")
+	}
+
+	n := p.MinLen + p.pick(ways[k][p.MinLen:])
+
+	// Choose the number of characters from each class, last class first.
+	counts := make([]int, k)
+	rem := n
+	for i := k; i > 0; i-- {
+		counts[i-1] = p.pick(splits(p.choices(p.Classes[i-1]), rem, ways[i-1]))
+		rem -= counts[i-1]
+	}
+
+	// Arrange the classes in a random order, by shuffling a list of class
+	// labels.
+	labels := make([]int, 0, n)
+	for i, c := range counts {
+		for j := 0; j < c; j++ {
+			labels = append(labels, i)
+		}
+	}
+	for i := len(labels) - 1; i > 0; i-- {
+		j := p.Src.Intn(i + 1)
+		labels[i], labels[j] = labels[j], labels[i]
+	}
+
+	// Fill in the characters of each class.
+	chars := make([][]byte, k)
+	for i, c := range p.Classes {
+		chars[i] = p.draw(c.Chars, counts[i])
+	}
+	b := make([]byte, n)
+	for pos, i := range labels {
+		b[pos] = chars[i][0]
+		chars[i] = chars[i][1:]
+	}
+	return string(b)
+}
+
+// Count returns the number of passwords allowed by p. It panics if p is
+// invalid.
+func (p Policy) Count() *big.Int {
+	return p.total(p.ways())
+}
+
+// Entropy returns the entropy of the passwords returned by New, in bits.
+// Since New is uniform, this is log2(Count). It panics if p is invalid, and
+// returns -Inf if p allows no passwords.
+func (p Policy) Entropy() float64 {
+	n := p.Count()
+	// Keep the top 64 bits of n, which determine its logarithm to within
+	// float64 precision.
+	shift := n.BitLen() - 64
+	if shift < 0 {
+		shift = 0
+	}
+	top := new(big.Int).Rsh(n, uint(shift))
+	f, _ := new(big.Float).SetInt(top).Float64()
+	return math.Log2(f) + float64(shift)
+}
+
+// Allows reports whether s is allowed by p. It panics if p is invalid.
+func (p Policy) Allows(s string) bool {
+	p.check()
+	if len(s) < p.MinLen || len(s) > p.MaxLen {
+		return false
+	}
+	counts := make([]int, len(p.Classes))
+	var seen [128]bool
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c >= 128 || (p.NoRepeats && seen[c]) {
+			return false
+		}
+		seen[c] = true
+		class := -1
+		for j, cl := range p.Classes {
+			if strings.IndexByte(cl.Chars, c) >= 0 {
+				class = j
+			}
+		}
+		if class < 0 {
+			return false
+		}
+		counts[class]++
+	}
+	for i, c := range p.Classes {
+		if counts[i] < c.Min {
+			return false
+		}
+	}
+	return true
+}
+
+// ways returns a table of the number of ways to fill positions with
+// characters: ways[i][n] is the number of strings of length n using only the
+// first i classes, that satisfy their minimums and, if NoRepeats is set,
+// have no repeated characters. It panics if p is invalid.
+func (p Policy) ways() [][]*big.Int {
+	p.check()
+	ways := make([][]*big.Int, len(p.Classes)+1)
+	for i := range ways {
+		ways[i] = make([]*big.Int, p.MaxLen+1)
+		for n := range ways[i] {
+			ways[i][n] = new(big.Int)
+		}
+	}
+	ways[0][0].SetInt64(1)
+	for i, c := range p.Classes {
+		f := p.choices(c)
+		for n := 0; n <= p.MaxLen; n++ {
+			for _, w := range splits(f, n, ways[i]) {
+				ways[i+1][n].Add(ways[i+1][n], w)
+			}
+		}
+	}
+	return ways
+}
+
+// choices returns the number of ways to choose the characters of class c
+// for a given set of positions: f[j] is the number of ways for j positions,
+// for j up to MaxLen. This is zero if j is below c.Min, or if NoRepeats is
+// set and c has fewer than j characters.
+func (p Policy) choices(c Class) []*big.Int {
+	f := make([]*big.Int, p.MaxLen+1)
+	t := big.NewInt(1)
+	s := int64(len(c.Chars))
+	for j := range f {
+		if j > 0 {
+			if p.NoRepeats {
+				if k := s - int64(j) + 1; k > 0 {
+					t.Mul(t, big.NewInt(k))
+				} else {
+					t.SetInt64(0)
+				}
+			} else {
+				t.Mul(t, big.NewInt(s))
+			}
+		}
+		f[j] = new(big.Int)
+		if j >= c.Min {
+			f[j].Set(t)
+		}
+	}
+	return f
+}
+
+// splits returns, for each j in [0,n], the number of strings of length n in
+// which exactly j characters are from a class with the given choices, and
+// the other n-j form one of rest[n-j] strings.
+func splits(f []*big.Int, n int, rest []*big.Int) []*big.Int {
+	w := make([]*big.Int, n+1)
+	binom := big.NewInt(1)
+	for j := range w {
+		if j > 0 {
+			binom.Mul(binom, big.NewInt(int64(n-j+1)))
+			binom.Quo(binom, big.NewInt(int64(j)))
+		}
+		w[j] = new(big.Int)
+		if f[j].Sign() != 0 && rest[n-j].Sign() != 0 {
+			w[j].Mul(binom, f[j]).Mul(w[j], rest[n-j])
+		}
+	}
+	return w
+}
+
+// total returns the number of allowed passwords, given the table computed by
+// ways.
+func (p Policy) total(ways [][]*big.Int) *big.Int {
+	total := new(big.Int)
+	for n := p.MinLen; n <= p.MaxLen; n++ {
+		total.Add(total, ways[len(p.Classes)][n])
+	}
+	return total
+}
+
+// pick returns a random index i with probability weights[i]/sum(weights),
+// where the sum is positive. It draws a uniform integer below the sum by
+// rejection sampling, and finds the weight it falls under.
+func (p Policy) pick(weights []*big.Int) int {
+	total := new(big.Int)
+	for _, w := range weights {
+		total.Add(total, w)
+	}
+	bits := total.BitLen()
+	buf := make([]byte, (bits+7)/8)
+	r := new(big.Int)
+	for {
+		p.Src.Read(buf)
+		// Clear the excess high bits, so that each try succeeds with
+		// probability at least 1/2.
+		buf[0] &= byte(0xFF >> (8*len(buf) - bits))
+		if r.SetBytes(buf).Cmp(total) < 0 {
+			break
+		}
+	}
+	for i, w := range weights {
+		if r.Cmp(w) < 0 {
+			return i
+		}
+		r.Sub(r, w)
+	}
+	panic("unreachable")
+}
+
+// draw returns n characters drawn uniformly from chars, without replacement
+// if NoRepeats is set.
+func (p Policy) draw(chars string, n int) []byte {
+	b := make([]byte, n)
+	if !p.NoRepeats {
+		for i := range b {
+			b[i] = chars[p.Src.Intn(len(chars))]
+		}
+		return b
+	}
+	// A partial Fisher-Yates shuffle.
+	pool := []byte(chars)
+	for i := range b {
+		j := i + p.Src.Intn(len(pool)-i)
+		pool[i], pool[j] = pool[j], pool[i]
+		b[i] = pool[i]
+	}
+	return b
+}
+
+// check panics if p is invalid.
+func (p Policy) check() {
+	if len(p.Classes) == 0 {
+		panic("fastrand: password policy has no classes")
+	}
+	if p.MinLen < 1 || p.MaxLen < p.MinLen {
+		panic("fastrand: password policy has an invalid length range")
+	}
+	var seen [128]bool
+	for _, c := range p.Classes {
+		if len(c.Chars) == 0 || c.Min < 0 {
+			panic("fastrand: password policy has an invalid class")
+		}
+		for i := 0; i < len(c.Chars); i++ {
+			ch := c.Chars[i]
+			if ch >= 128 {
+				panic("fastrand: password class contains a non-ASCII byte")
+			}
+			if seen[ch] {
+				panic("fastrand: password classes contain a repeated character")
+			}
+			seen[ch] = true
+		}
+	}
+}
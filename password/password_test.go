@@ -0,0 +1,216 @@
+package password
+
+import (
+	"math"
+	"math/big"
+	"strings"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// enumerate returns every string allowed by p, by brute force.
+func enumerate(p Policy) []string {
+	var alphabet string
+	for _, c := range p.Classes {
+		alphabet += c.Chars
+	}
+	var all []string
+	var rec func(prefix string)
+	rec = func(prefix string) {
+		if p.Allows(prefix) {
+			all = append(all, prefix)
+		}
+		if len(prefix) == p.MaxLen {
+			return
+		}
+		for i := range alphabet {
+			rec(prefix + alphabet[i:i+1])
+		}
+	}
+	rec("")
+	return all
+}
+
+// TestUniform tests that New is uniform over the passwords allowed by small
+// policies, and that Count matches their number.
+func TestUniform(t *testing.T) {
+	for _, p := range []Policy{
+		{Classes: []Class{{"ab", 1}, {"01", 1}}, MinLen: 2, MaxLen: 4},
+		{Classes: []Class{{"abc", 1}, {"01", 2}, {"#", 0}}, MinLen: 3, MaxLen: 5, NoRepeats: true},
+		{Classes: []Class{{"xyz", 2}}, MinLen: 1, MaxLen: 3},
+	} {
+		all := enumerate(p)
+		if p.Count().Cmp(big.NewInt(int64(len(all)))) != 0 {
+			t.Errorf("%+v: Count is %v, expected %v", p, p.Count(), len(all))
+			continue
+		}
+		index := make(map[string]int)
+		for i, s := range all {
+			index[s] = i
+		}
+		counts := make([]int, len(all))
+		iters := 100 * len(all)
+		for i := 0; i < iters; i++ {
+			s := p.New()
+			j, ok := index[s]
+			if !ok {
+				t.Fatalf("%+v: New returned disallowed password %q", p, s)
+			}
+			counts[j]++
+		}
+		e := float64(iters) / float64(len(all))
+		var stat float64
+		for _, c := range counts {
+			stat += (float64(c) - e) * (float64(c) - e) / e
+		}
+		if crit := chiSquareCritical(len(all) - 1); stat > crit {
+			t.Errorf("%+v: chi-square statistic %.2f exceeds critical value %.2f", p, stat, crit)
+		}
+	}
+}
+
+// TestPlacement tests that the required characters of a typical policy are
+// equally likely to appear at every position, rather than at the positions
+// where a generate-then-patch approach would put them.
+func TestPlacement(t *testing.T) {
+	p := Policy{
+		Classes:   []Class{{Lower, 0}, {Upper, 1}, {Digits, 1}, {Symbols, 1}},
+		MinLen:    16,
+		MaxLen:    16,
+		NoRepeats: true,
+	}
+	const iters = 20000
+	classes := []string{Lower, Upper, Digits, Symbols}
+	counts := make([][16]int, len(classes))
+	for i := 0; i < iters; i++ {
+		s := p.New()
+		if !p.Allows(s) {
+			t.Fatalf("New returned disallowed password %q", s)
+		}
+		for pos := range s {
+			for c, chars := range classes {
+				if strings.IndexByte(chars, s[pos]) >= 0 {
+					counts[c][pos]++
+				}
+			}
+		}
+	}
+	// Each position's class has the same distribution, so the counts at
+	// every position should agree with their mean.
+	for c := range counts {
+		var total int
+		for _, n := range counts[c] {
+			total += n
+		}
+		mean := float64(total) / 16
+		q := mean / iters
+		sd := math.Sqrt(iters * q * (1 - q))
+		for pos, n := range counts[c] {
+			if math.Abs(float64(n)-mean) > 5*sd {
+				t.Errorf("class %v appears at position %v %v times, expected about %.0f", c, pos, n, mean)
+			}
+		}
+	}
+}
+
+// TestEntropy tests Entropy against policies whose counts are known.
+func TestEntropy(t *testing.T) {
+	// Without requirements, every string over the alphabet is allowed.
+	p := Policy{Classes: []Class{{Lower, 0}, {Digits, 0}}, MinLen: 20, MaxLen: 20}
+	if e, exp := p.Entropy(), 20*math.Log2(36); math.Abs(e-exp) > 1e-9 {
+		t.Errorf("Entropy is %v, expected %v", e, exp)
+	}
+	// Requiring a digit excludes the strings of letters only.
+	p.Classes[1].Min = 1
+	exp := math.Log2(math.Pow(36, 20) - math.Pow(26, 20))
+	if e := p.Entropy(); math.Abs(e-exp) > 1e-9 {
+		t.Errorf("Entropy is %v, expected %v", e, exp)
+	}
+	// Without repeats, the passwords are the 20-permutations of 36
+	// characters, less those of letters only.
+	p.NoRepeats = true
+	perms := func(n, k int64) float64 {
+		f := 1.0
+		for i := int64(0); i < k; i++ {
+			f *= float64(n - i)
+		}
+		return f
+	}
+	exp = math.Log2(perms(36, 20) - perms(26, 20))
+	if e := p.Entropy(); math.Abs(e-exp) > 1e-9 {
+		t.Errorf("Entropy is %v, expected %v", e, exp)
+	}
+	// Huge counts do not overflow.
+	p = Policy{Classes: []Class{{Lower, 1}, {Upper, 1}}, MinLen: 200, MaxLen: 200}
+	if e, exp := p.Entropy(), 200*math.Log2(52); math.Abs(e-exp) > 1e-6 {
+		t.Errorf("Entropy is %v, expected %v", e, exp)
+	}
+	// A policy that allows nothing.
+	p = Policy{Classes: []Class{{"ab", 3}}, MinLen: 1, MaxLen: 5, NoRepeats: true}
+	if e := p.Entropy(); !math.IsInf(e, -1) {
+		t.Errorf("Entropy is %v, expected -Inf", e)
+	}
+}
+
+// TestSrc tests that passwords are determined by a seeded Src.
+func TestSrc(t *testing.T) {
+	p := Policy{Classes: []Class{{Lower, 1}, {Digits, 1}}, MinLen: 8, MaxLen: 12}
+	p.Src = fastrand.NewSeededGenerator([]byte("seed"))
+	a := p.New()
+	p.Src = fastrand.NewSeededGenerator([]byte("seed"))
+	if b := p.New(); a != b {
+		t.Error("seeded Policies produced different passwords")
+	}
+}
+
+// TestPanics tests that New panics on invalid policies.
+func TestPanics(t *testing.T) {
+	for _, p := range []Policy{
+		{MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{Lower, 0}}, MinLen: 0, MaxLen: 8},
+		{Classes: []Class{{Lower, 0}}, MinLen: 9, MaxLen: 8},
+		{Classes: []Class{{"", 0}}, MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{Lower, -1}}, MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{"abca", 0}}, MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{"abc", 0}, {"cde", 0}}, MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{"é", 0}}, MinLen: 8, MaxLen: 8},
+		{Classes: []Class{{Digits, 0}}, MinLen: 11, MaxLen: 12, NoRepeats: true},
+	} {
+		if !panics(func() { p.New() }) {
+			t.Errorf("expected panic for %+v", p)
+		}
+	}
+}
+
+// BenchmarkNew benchmarks New with a typical policy.
+func BenchmarkNew(b *testing.B) {
+	p := Policy{
+		Classes:   []Class{{Lower, 0}, {Upper, 1}, {Digits, 1}, {Symbols, 1}},
+		MinLen:    16,
+		MaxLen:    24,
+		NoRepeats: true,
+	}
+	for i := 0; i < b.N; i++ {
+		_ = p.New()
+	}
+}
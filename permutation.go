@@ -0,0 +1,89 @@
+package fastrand
+
+import (
+	"encoding/binary"
+	"math/bits"
+	"strconv"
+
+	"golang.org/x/crypto/blake2b"
+)
+
+// feistelRounds is the number of rounds used by a Permutation's Feistel
+// network. Four rounds suffice for a secure pseudorandom permutation over
+// large domains, but small domains need more before the permutation is
+// statistically indistinguishable from a random one.
+const feistelRounds = 8
+
+// A Permutation is a random permutation of [0,n) that is computed lazily,
+// using O(1) memory regardless of n. It is safe for concurrent use by
+// multiple goroutines.
+//
+// The permutation is a keyed Feistel network over the smallest domain of an
+// even number of bits that contains [0,n), with blake2b as the round
+// function. Values that fall outside [0,n) are mapped back into it by
+// repeatedly applying the network ("cycle walking"). Since the domain is less
+// than 4n, each call to At applies the network fewer than 4 times on average.
+type Permutation struct {
+	n    uint64
+	half uint // number of bits in each half of the Feistel network
+	key  [32]byte
+}
+
+// NewPermutation returns a random permutation of [0,n), keyed with entropy
+// from Reader.
+func NewPermutation(n uint64) *Permutation {
+	p := &Permutation{n: n}
+	p.half = 1
+	if n > 1 {
+		p.half = uint(bits.Len64(n-1)+1) / 2
+	}
+	Read(p.key[:])
+	return p
+}
+
+// Len returns n, the number of elements being permuted.
+func (p *Permutation) Len() uint64 {
+	return p.n
+}
+
+// At returns the element at position i of the permutation. It panics if
+// i >= n.
+func (p *Permutation) At(i uint64) uint64 {
+	if i >= p.n {
+		panic("fastrand: index " + strconv.FormatUint(i, 10) + " out of range for Permutation of length " + strconv.FormatUint(p.n, 10))
+	}
+	x := p.encrypt(i)
+	for x >= p.n {
+		x = p.encrypt(x)
+	}
+	return x
+}
+
+// Values returns an iterator over the elements of the permutation, in
+// permuted order. The iterator has the underlying type of iter.Seq[uint64],
+// so it can be ranged over with Go 1.23 or later, or called directly.
+func (p *Permutation) Values() func(yield func(uint64) bool) {
+	return func(yield func(uint64) bool) {
+		for i := uint64(0); i < p.n; i++ {
+			if !yield(p.At(i)) {
+				return
+			}
+		}
+	}
+}
+
+// encrypt applies the Feistel network to x, which must be less than
+// 2^(2*p.half).
+func (p *Permutation) encrypt(x uint64) uint64 {
+	mask := uint64(1)<<p.half - 1
+	l, r := x>>p.half, x&mask
+	var buf [len(p.key) + 1 + 8]byte
+	copy(buf[:], p.key[:])
+	for round := 0; round < feistelRounds; round++ {
+		buf[len(p.key)] = byte(round)
+		binary.LittleEndian.PutUint64(buf[len(p.key)+1:], r)
+		h := blake2b.Sum256(buf[:])
+		l, r = r, l^(binary.LittleEndian.Uint64(h[:])&mask)
+	}
+	return l<<p.half | r
+}
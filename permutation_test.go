@@ -0,0 +1,88 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// TestPermutationBijection tests that a Permutation maps [0,n) onto itself
+// for a range of sizes, including ones just above and below powers of two.
+func TestPermutationBijection(t *testing.T) {
+	for _, n := range []uint64{1, 2, 3, 4, 5, 15, 16, 17, 63, 64, 65, 1000, 4097} {
+		p := NewPermutation(n)
+		if p.Len() != n {
+			t.Fatalf("expected Len of %v, got %v", n, p.Len())
+		}
+		seen := make([]bool, n)
+		var count uint64
+		p.Values()(func(x uint64) bool {
+			if x >= n || seen[x] {
+				t.Fatalf("n = %v: value %v out of range or repeated", n, x)
+			}
+			seen[x] = true
+			count++
+			return true
+		})
+		if count != n {
+			t.Fatalf("n = %v: iterator yielded %v values", n, count)
+		}
+	}
+}
+
+// TestPermutationPanics tests that At panics for out of range indices.
+func TestPermutationPanics(t *testing.T) {
+	if !panics(func() { NewPermutation(0).At(0) }) {
+		t.Error("expected panic for empty permutation")
+	}
+	if !panics(func() { NewPermutation(10).At(10) }) {
+		t.Error("expected panic for i == n")
+	}
+	if panics(func() { NewPermutation(math.MaxUint64).At(math.MaxUint64 - 1) }) {
+		t.Error("did not expect panic for maximal n")
+	}
+}
+
+// TestPermutationHuge tests that a Permutation over a huge range is
+// consistent, and that the iterator can be stopped early.
+func TestPermutationHuge(t *testing.T) {
+	p := NewPermutation(1 << 40)
+	seen := make(map[uint64]bool)
+	var count int
+	p.Values()(func(x uint64) bool {
+		if x >= 1<<40 || seen[x] {
+			t.Fatalf("value %v out of range or repeated", x)
+		}
+		if p.At(uint64(count)) != x {
+			t.Fatal("At and Values disagree")
+		}
+		seen[x] = true
+		count++
+		return count < 1000
+	})
+	if count != 1000 {
+		t.Errorf("expected the iterator to stop after 1000 values, got %v", count)
+	}
+}
+
+// TestPermutationUniform tests that each element is equally likely to be
+// mapped to each position across different keys.
+func TestPermutationUniform(t *testing.T) {
+	const n = 10
+	probs := make([]float64, n*n)
+	for i := range probs {
+		probs[i] = 1.0 / (n * n)
+	}
+	checkChiSquare(t, 50000, probs, func() int {
+		p := NewPermutation(n)
+		i := uint64(Intn(n))
+		return int(i*n + p.At(i))
+	})
+}
+
+// BenchmarkPermutationAt benchmarks the At method on a huge permutation.
+func BenchmarkPermutationAt(b *testing.B) {
+	p := NewPermutation(1 << 40)
+	for i := 0; i < b.N; i++ {
+		_ = p.At(uint64(i))
+	}
+}
@@ -0,0 +1,71 @@
+package qmc
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// LatinHypercube returns a Latin hypercube sample of n points in [0,1)^dim.
+// Each axis is divided into n equal strata, and each stratum of each axis
+// contains exactly one point, placed uniformly within it. The strata are
+// matched up across axes by independent random permutations. It panics if
+// n < 1 or dim < 1.
+func LatinHypercube(n, dim int, src *fastrand.Generator) [][]float64 {
+	if n < 1 || dim < 1 {
+		panic("fastrand: invalid LatinHypercube size")
+	}
+	points := make([][]float64, n)
+	for i := range points {
+		points[i] = make([]float64, dim)
+	}
+	strata := make([]int, n)
+	for d := 0; d < dim; d++ {
+		for i := range strata {
+			strata[i] = i
+		}
+		for i := n - 1; i > 0; i-- {
+			j := src.Intn(i + 1)
+			strata[i], strata[j] = strata[j], strata[i]
+		}
+		for i, p := range points {
+			p[d] = belowOne((float64(strata[i]) + src.Float64()) / float64(n))
+		}
+	}
+	return points
+}
+
+// Jittered returns a jittered stratified sample of [0,1)^dim: each axis is
+// divided into n equal strata, and each of the n^dim cells they form
+// contains one point, placed uniformly within it. The points are ordered by
+// cell, with the first axis varying slowest. It panics if n < 1, dim < 1, or
+// n^dim overflows an int.
+func Jittered(n, dim int, src *fastrand.Generator) [][]float64 {
+	if n < 1 || dim < 1 {
+		panic("fastrand: invalid Jittered size")
+	}
+	total := 1
+	for d := 0; d < dim; d++ {
+		if total > math.MaxInt/n {
+			panic("fastrand: Jittered sample is too large")
+		}
+		total *= n
+	}
+	points := make([][]float64, total)
+	cell := make([]int, dim)
+	for i := range points {
+		p := make([]float64, dim)
+		for d, c := range cell {
+			p[d] = belowOne((float64(c) + src.Float64()) / float64(n))
+		}
+		points[i] = p
+		// Advance to the next cell, like an odometer.
+		for d := dim - 1; d >= 0; d-- {
+			if cell[d]++; cell[d] < n {
+				break
+			}
+			cell[d] = 0
+		}
+	}
+	return points
+}
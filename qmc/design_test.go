@@ -0,0 +1,82 @@
+package qmc
+
+import (
+	"math"
+	"testing"
+)
+
+// TestLatinHypercube tests that every axis of a Latin hypercube sample is
+// stratified, and that the strata and offsets are uniformly random.
+func TestLatinHypercube(t *testing.T) {
+	points := LatinHypercube(50, 4, nil)
+	if len(points) != 50 {
+		t.Fatalf("expected 50 points, got %v", len(points))
+	}
+	for d := 0; d < 4; d++ {
+		xs := make([]float64, len(points))
+		for i, p := range points {
+			xs[i] = p[d]
+		}
+		checkStrata(t, "LatinHypercube", xs, 50)
+	}
+
+	// Over many samples, the first point's stratum is uniform, and so is
+	// its offset within it.
+	const n, iters = 5, 10000
+	counts := make([]int, n)
+	offsets := make([]float64, iters)
+	for i := range offsets {
+		x := LatinHypercube(n, 2, nil)[0][1] * n
+		counts[int(x)]++
+		offsets[i] = x - math.Floor(x)
+	}
+	var stat float64
+	for _, c := range counts {
+		e := float64(iters) / n
+		stat += (float64(c) - e) * (float64(c) - e) / e
+	}
+	if crit := chiSquareCritical(n - 1); stat > crit {
+		t.Errorf("LatinHypercube strata are not uniform: %v", counts)
+	}
+	checkUniformKS(t, "LatinHypercube", offsets)
+}
+
+// TestJittered tests that a jittered sample has one point in each cell, in
+// order, and that the offsets within cells are uniform.
+func TestJittered(t *testing.T) {
+	const n = 4
+	points := Jittered(n, 3, nil)
+	if len(points) != n*n*n {
+		t.Fatalf("expected %v points, got %v", n*n*n, len(points))
+	}
+	var offsets []float64
+	for i, p := range points {
+		cell := [3]int{i / (n * n), i / n % n, i % n}
+		for d, x := range p {
+			if int(x*n) != cell[d] {
+				t.Fatalf("point %v is %v, outside cell %v", i, p, cell)
+			}
+		}
+	}
+	for i := 0; i < 5000; i++ {
+		x := Jittered(n, 1, nil)[2][0] * n
+		offsets = append(offsets, x-2)
+	}
+	checkUniformKS(t, "Jittered", offsets)
+}
+
+// TestDesignPanics tests that LatinHypercube and Jittered panic on invalid
+// sizes.
+func TestDesignPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"LatinHypercube(0, 1)": func() { LatinHypercube(0, 1, nil) },
+		"LatinHypercube(1, 0)": func() { LatinHypercube(1, 0, nil) },
+		"Jittered(0, 1)":       func() { Jittered(0, 1, nil) },
+		"Jittered(1, 0)":       func() { Jittered(1, 0, nil) },
+		"Jittered(1000, 10)":   func() { Jittered(1000, 10, nil) },
+	} {
+		if !panics(fn) {
+			t.Errorf("expected panic for %v", name)
+		}
+	}
+}
@@ -0,0 +1,112 @@
+package qmc
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// MaxHaltonDim is the largest dimension supported by Halton.
+const MaxHaltonDim = 100
+
+// haltonBases holds the first MaxHaltonDim primes.
+var haltonBases = func() []uint64 {
+	var primes []uint64
+	for n := uint64(2); len(primes) < MaxHaltonDim; n++ {
+		prime := true
+		for _, p := range primes {
+			if p*p > n {
+				break
+			}
+			if n%p == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			primes = append(primes, n)
+		}
+	}
+	return primes
+}()
+
+// A Halton is an Owen-scrambled Halton sequence. Coordinate d is the radical
+// inverse of the index in base b, the d'th prime, so the first b^m points of
+// that coordinate contain exactly one point in each interval [k/b^m,
+// (k+1)/b^m). It is safe for concurrent use by multiple goroutines.
+type Halton struct {
+	dim  int
+	keys []uint64 // one scrambling key per dimension; nil for no scrambling
+}
+
+// NewHalton returns a scrambled Halton sequence of dimension dim, with the
+// scramble drawn from src. It panics if dim is not in [1,MaxHaltonDim].
+func NewHalton(dim int, src *fastrand.Generator) *Halton {
+	checkDim(dim, MaxHaltonDim)
+	return &Halton{dim: dim, keys: keys(dim, src)}
+}
+
+// Dim returns the dimension of the sequence.
+func (h *Halton) Dim() int {
+	return h.dim
+}
+
+// Point returns point i of the sequence, in [0,1)^Dim.
+func (h *Halton) Point(i uint64) []float64 {
+	p := make([]float64, h.dim)
+	for d := range p {
+		b := haltonBases[d]
+		if h.keys == nil {
+			p[d] = radicalInverse(i, b)
+		} else {
+			p[d] = owenScrambleBase(i, b, h.keys[d])
+		}
+	}
+	return p
+}
+
+// radicalInverse returns the radical inverse of i in base b: the fraction
+// whose base-b digits are those of i, reflected about the radix point.
+func radicalInverse(i, b uint64) float64 {
+	var x float64
+	scale := 1 / float64(b)
+	for ; i > 0; i /= b {
+		x += float64(i%b) * scale
+		scale /= float64(b)
+	}
+	return belowOne(x)
+}
+
+// owenScrambleBase returns the radical inverse of i in base b, with a nested
+// uniform scramble applied to its digits: each digit is mapped through a
+// random permutation of the base-b digits that depends on the digits before
+// it. Digits are scrambled until they no longer affect a float64, so trailing
+// zero digits become random too.
+func owenScrambleBase(i, b, key uint64) float64 {
+	depth := int(math.Ceil(53 / math.Log2(float64(b))))
+	var x float64
+	scale := 1 / float64(b)
+	node := key // identifies the digits seen so far
+	for j := 0; j < depth; j++ {
+		digit := i % b
+		i /= b
+		x += float64(permuteDigit(digit, b, node)) * scale
+		scale /= float64(b)
+		node = mix(node, digit+1)
+	}
+	return belowOne(x)
+}
+
+// permuteDigit returns the image of digit under a random permutation of
+// [0,b) determined by node. The permutation ranks the digits by their hashes,
+// which orders them uniformly at random.
+func permuteDigit(digit, b, node uint64) uint64 {
+	h := mix(node, digit)
+	var rank uint64
+	for e := uint64(0); e < b; e++ {
+		if he := mix(node, e); he < h || (he == h && e < digit) {
+			rank++
+		}
+	}
+	return rank
+}
@@ -0,0 +1,119 @@
+package qmc
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// TestHaltonBases tests that the bases are the first primes.
+func TestHaltonBases(t *testing.T) {
+	if !reflect.DeepEqual(haltonBases[:6], []uint64{2, 3, 5, 7, 11, 13}) {
+		t.Errorf("unexpected bases %v", haltonBases[:6])
+	}
+	if len(haltonBases) != MaxHaltonDim || haltonBases[MaxHaltonDim-1] != 541 {
+		t.Errorf("expected the 100th prime to be 541, got %v", haltonBases[len(haltonBases)-1])
+	}
+}
+
+// TestRadicalInverse tests the radicalInverse function.
+func TestRadicalInverse(t *testing.T) {
+	for _, test := range []struct {
+		i, b uint64
+		x    float64
+	}{
+		{0, 2, 0},
+		{1, 2, 0.5},
+		{2, 2, 0.25},
+		{3, 2, 0.75},
+		{6, 2, 0.375},
+		{1, 3, 1.0 / 3},
+		{5, 3, 2.0/3 + 1.0/9},
+		{7, 5, 2.0/5 + 1.0/25},
+	} {
+		if x := radicalInverse(test.i, test.b); math.Abs(x-test.x) > 1e-15 {
+			t.Errorf("radicalInverse(%v, %v) = %v, expected %v", test.i, test.b, x, test.x)
+		}
+	}
+}
+
+// TestPermuteDigit tests that permuteDigit is a permutation for each node.
+func TestPermuteDigit(t *testing.T) {
+	for _, b := range []uint64{2, 3, 7, 541} {
+		for node := uint64(0); node < 10; node++ {
+			seen := make([]bool, b)
+			for d := uint64(0); d < b; d++ {
+				p := permuteDigit(d, b, node)
+				if p >= b || seen[p] {
+					t.Fatalf("permuteDigit is not a permutation of [0,%v)", b)
+				}
+				seen[p] = true
+			}
+		}
+	}
+}
+
+// TestHaltonStrata tests that the first b^m points of each coordinate are
+// stratified, with and without scrambling.
+func TestHaltonStrata(t *testing.T) {
+	for _, h := range []*Halton{{dim: 5}, NewHalton(5, nil)} {
+		for d := 0; d < h.Dim(); d++ {
+			b := haltonBases[d]
+			n := b
+			for n*b <= 2000 {
+				n *= b
+			}
+			xs := make([]float64, n)
+			for i := range xs {
+				xs[i] = h.Point(uint64(i))[d]
+			}
+			checkStrata(t, "Halton", xs, int(n))
+		}
+	}
+}
+
+// TestHaltonScrambleUniform tests that each point of a scrambled sequence is
+// uniformly distributed over independent scrambles, in several bases.
+func TestHaltonScrambleUniform(t *testing.T) {
+	const n = 5000
+	for _, i := range []uint64{0, 7, 123456} {
+		for _, b := range []uint64{2, 5, haltonBases[MaxHaltonDim-1]} {
+			xs := make([]float64, n)
+			for j := range xs {
+				xs[j] = owenScrambleBase(i, b, keys(1, nil)[0])
+			}
+			checkUniformKS(t, "Halton", xs)
+		}
+	}
+}
+
+// TestHaltonReproducible tests that a seeded Generator reproduces the same
+// scramble.
+func TestHaltonReproducible(t *testing.T) {
+	a := NewHalton(5, fastrand.NewSeededGenerator([]byte("seed")))
+	b := NewHalton(5, fastrand.NewSeededGenerator([]byte("seed")))
+	for i := uint64(0); i < 100; i++ {
+		if p, q := a.Point(i), b.Point(i); !reflect.DeepEqual(p, q) {
+			t.Fatalf("point %v differs: %v and %v", i, p, q)
+		}
+	}
+}
+
+// TestHaltonPanics tests that Halton panics on invalid dimensions.
+func TestHaltonPanics(t *testing.T) {
+	for _, dim := range []int{0, MaxHaltonDim + 1} {
+		if !panics(func() { NewHalton(dim, nil) }) {
+			t.Errorf("expected panic for dimension %v", dim)
+		}
+	}
+}
+
+// BenchmarkHalton benchmarks drawing a scrambled 10-dimensional point.
+func BenchmarkHalton(b *testing.B) {
+	h := NewHalton(10, nil)
+	for i := 0; i < b.N; i++ {
+		_ = h.Point(uint64(i))
+	}
+}
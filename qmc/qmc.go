@@ -0,0 +1,61 @@
+// Package qmc provides space-filling designs and randomized quasi-Monte Carlo
+// sequences for numerical integration and parameter sweeps.
+//
+// LatinHypercube and Jittered produce stratified random designs. Sobol and
+// Halton are low-discrepancy sequences, randomized by nested uniform (Owen)
+// scrambling, which keeps their stratification properties while making each
+// point uniformly distributed, so that averages over them are unbiased and
+// their variance can be estimated from independent scramblings.
+//
+// All randomness comes from a *fastrand.Generator. A nil Generator draws
+// from fastrand.Reader; a Generator from fastrand.NewSeededGenerator gives a
+// reproducible design.
+package qmc
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// mix returns a pseudorandom function of key and x. It is a fast mixer
+// built from the splitmix64 finalizer, not a cryptographic hash; the
+// scrambles only need their flips and permutations to be statistically
+// independent, and their keys come from a Generator.
+func mix(key, x uint64) uint64 {
+	z := x ^ key
+	for i := 0; i < 2; i++ {
+		z ^= z >> 30
+		z *= 0xbf58476d1ce4e5b9
+		z ^= z >> 27
+		z *= 0x94d049bb133111eb
+		z ^= z >> 31
+		z += key
+	}
+	return z
+}
+
+// keys returns n random scrambling keys drawn from src.
+func keys(n int, src *fastrand.Generator) []uint64 {
+	k := make([]uint64, n)
+	for i := range k {
+		k[i] = src.Uint64()
+	}
+	return k
+}
+
+// belowOne returns x, or the largest float64 below 1 if rounding has carried
+// x up to 1.
+func belowOne(x float64) float64 {
+	if x >= 1 {
+		return math.Nextafter(1, 0)
+	}
+	return x
+}
+
+// checkDim panics if dim is not in [1,max].
+func checkDim(dim, max int) {
+	if dim < 1 || dim > max {
+		panic("fastrand: qmc dimension out of range")
+	}
+}
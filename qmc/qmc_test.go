@@ -0,0 +1,85 @@
+package qmc
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// ksCritical is the coefficient of the Kolmogorov-Smirnov critical value at
+// a significance level of about 1e-4.
+const ksCritical = 2.23
+
+// checkUniformKS fails the test if the Kolmogorov-Smirnov statistic of xs
+// against the uniform distribution on [0,1) exceeds the critical value. It
+// sorts xs.
+func checkUniformKS(t *testing.T, name string, xs []float64) {
+	t.Helper()
+	sort.Float64s(xs)
+	n := float64(len(xs))
+	var stat float64
+	for i, x := range xs {
+		stat = math.Max(stat, math.Max(float64(i+1)/n-x, x-float64(i)/n))
+	}
+	if crit := ksCritical / math.Sqrt(n); stat > crit {
+		t.Errorf("%v: Kolmogorov-Smirnov statistic %.4f exceeds critical value %.4f", name, stat, crit)
+	}
+}
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkStrata fails the test unless xs has exactly the same number of values
+// in each of the intervals [k/n, (k+1)/n). A small tolerance allows for
+// values that should lie exactly on a boundary but were rounded below it.
+func checkStrata(t *testing.T, name string, xs []float64, n int) {
+	t.Helper()
+	counts := make([]int, n)
+	for _, x := range xs {
+		if !(0 <= x && x < 1) {
+			t.Fatalf("%v: value %v is outside [0,1)", name, x)
+		}
+		k := int(x*float64(n) + 1e-9)
+		if k > n-1 {
+			k = n - 1
+		}
+		counts[k]++
+	}
+	for k, c := range counts {
+		if c != len(xs)/n {
+			t.Errorf("%v: interval %v of %v contains %v values, expected %v", name, k, n, c, len(xs)/n)
+			return
+		}
+	}
+}
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// TestMix tests that mix depends on both its key and its input, and that
+// its top bit is balanced.
+func TestMix(t *testing.T) {
+	if mix(1, 2) == mix(1, 3) || mix(1, 2) == mix(2, 2) {
+		t.Error("mix ignores an argument")
+	}
+	var ones int
+	for x := uint64(0); x < 10000; x++ {
+		ones += int(mix(12345, x) >> 63)
+	}
+	if ones < 4700 || ones > 5300 {
+		t.Errorf("expected roughly 5000 top bits set, got %v", ones)
+	}
+}
@@ -0,0 +1,134 @@
+package qmc
+
+import (
+	"github.com/NebulousLabs/fastrand"
+)
+
+// MaxSobolDim is the largest dimension supported by Sobol.
+const MaxSobolDim = 21
+
+// sobolParams holds the primitive polynomials and initial direction numbers
+// for dimensions 2 and up, from Joe and Kuo, "Constructing Sobol Sequences
+// with Better Two-Dimensional Projections" (2008). The polynomial of degree s
+// is x^s + a_1 x^(s-1) + ... + a_(s-1) x + 1, where a_1 is the top bit of the
+// (s-1)-bit value a.
+var sobolParams = [MaxSobolDim - 1]struct {
+	s, a uint32
+	m    []uint32
+}{
+	{1, 0, []uint32{1}},
+	{2, 1, []uint32{1, 3}},
+	{3, 1, []uint32{1, 3, 1}},
+	{3, 2, []uint32{1, 1, 1}},
+	{4, 1, []uint32{1, 1, 3, 3}},
+	{4, 4, []uint32{1, 3, 5, 13}},
+	{5, 2, []uint32{1, 1, 5, 5, 17}},
+	{5, 4, []uint32{1, 1, 5, 5, 5}},
+	{5, 7, []uint32{1, 1, 7, 11, 19}},
+	{5, 11, []uint32{1, 1, 5, 1, 1}},
+	{5, 13, []uint32{1, 1, 1, 3, 11}},
+	{5, 14, []uint32{1, 3, 5, 5, 31}},
+	{6, 1, []uint32{1, 3, 3, 9, 7, 49}},
+	{6, 13, []uint32{1, 1, 1, 15, 21, 21}},
+	{6, 16, []uint32{1, 3, 1, 13, 27, 49}},
+	{6, 19, []uint32{1, 1, 1, 15, 7, 5}},
+	{6, 22, []uint32{1, 3, 1, 15, 13, 25}},
+	{6, 25, []uint32{1, 1, 5, 5, 19, 61}},
+	{7, 1, []uint32{1, 3, 7, 11, 23, 15, 103}},
+	{7, 4, []uint32{1, 3, 7, 13, 13, 15, 69}},
+}
+
+// sobolDirections returns the 32 direction numbers of dimension d, counting
+// from 0, as binary fractions with 32 bits.
+func sobolDirections(d int) [32]uint32 {
+	var v [32]uint32
+	if d == 0 {
+		// The first dimension is the van der Corput sequence.
+		for i := range v {
+			v[i] = 1 << (31 - uint(i))
+		}
+		return v
+	}
+	p := sobolParams[d-1]
+	for i, m := range p.m {
+		v[i] = m << (31 - uint(i))
+	}
+	for i := int(p.s); i < len(v); i++ {
+		v[i] = v[i-int(p.s)] ^ v[i-int(p.s)]>>p.s
+		for k := 1; k < int(p.s); k++ {
+			v[i] ^= (p.a >> (p.s - 1 - uint32(k)) & 1) * v[i-k]
+		}
+	}
+	return v
+}
+
+// A Sobol is an Owen-scrambled Sobol sequence. The first 2^m points of each
+// coordinate contain exactly one point in each interval [k/2^m, (k+1)/2^m),
+// and the first two coordinates form a (0,m,2)-net: every rectangle of area
+// 2^-m with dyadic sides contains exactly one point. It is safe for
+// concurrent use by multiple goroutines.
+type Sobol struct {
+	dirs [][32]uint32
+	keys []uint64 // one scrambling key per dimension; nil for no scrambling
+}
+
+// NewSobol returns a scrambled Sobol sequence of dimension dim, with the
+// scramble drawn from src. It panics if dim is not in [1,MaxSobolDim].
+func NewSobol(dim int, src *fastrand.Generator) *Sobol {
+	s := newSobol(dim)
+	s.keys = keys(dim, src)
+	return s
+}
+
+// newSobol returns an unscrambled Sobol sequence of dimension dim.
+func newSobol(dim int) *Sobol {
+	checkDim(dim, MaxSobolDim)
+	s := &Sobol{dirs: make([][32]uint32, dim)}
+	for d := range s.dirs {
+		s.dirs[d] = sobolDirections(d)
+	}
+	return s
+}
+
+// Dim returns the dimension of the sequence.
+func (s *Sobol) Dim() int {
+	return len(s.dirs)
+}
+
+// Point returns point i of the sequence, in [0,1)^Dim. Points are in Gray
+// code order. It panics if i >= 2^32.
+func (s *Sobol) Point(i uint64) []float64 {
+	if i >= 1<<32 {
+		panic("fastrand: Sobol index is too large")
+	}
+	gray := i ^ i>>1
+	p := make([]float64, len(s.dirs))
+	for d, v := range s.dirs {
+		var x uint32
+		for j := 0; gray>>uint(j) != 0; j++ {
+			if gray>>uint(j)&1 == 1 {
+				x ^= v[j]
+			}
+		}
+		if s.keys == nil {
+			p[d] = float64(x) / (1 << 32)
+		} else {
+			p[d] = float64(owenScramble2(x, s.keys[d])) / (1 << 53)
+		}
+	}
+	return p
+}
+
+// owenScramble2 applies a nested uniform scramble to the binary fraction x,
+// returning a 53-bit fraction. Each digit is flipped or not according to a
+// random function of the digits before it, and the digits beyond the 32nd,
+// which are zero in x, are likewise scrambled into random digits.
+func owenScramble2(x uint32, key uint64) uint64 {
+	var flips uint32
+	for d := uint(0); d < 32; d++ {
+		prefix := uint64(x) >> (32 - d)
+		flips |= uint32(mix(key, uint64(d)<<32|prefix)>>63) << (31 - d)
+	}
+	low := mix(key, 32<<32|uint64(x)) >> 43
+	return uint64(x^flips)<<21 | low
+}
@@ -0,0 +1,139 @@
+package qmc
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// isPrimitive reports whether the polynomial over GF(2) with the given bits
+// and degree s is primitive, that is, whether x has order 2^s-1 modulo it.
+func isPrimitive(poly uint32, s uint) bool {
+	x := uint32(1)
+	for k := uint32(1); k < 1<<s; k++ {
+		x <<= 1
+		if x&(1<<s) != 0 {
+			x ^= poly
+		}
+		if x == 1 {
+			return k == 1<<s-1
+		}
+	}
+	return false
+}
+
+// TestSobolParams tests that every polynomial in the table is primitive and
+// distinct, and that the initial direction numbers are valid.
+func TestSobolParams(t *testing.T) {
+	seen := make(map[uint32]bool)
+	for d, p := range sobolParams {
+		poly := 1<<p.s | p.a<<1 | 1
+		if p.a >= 1<<(p.s-1) || !isPrimitive(poly, uint(p.s)) {
+			t.Errorf("dimension %v: polynomial %b is not primitive", d+2, poly)
+		}
+		if seen[poly] {
+			t.Errorf("dimension %v: polynomial %b is repeated", d+2, poly)
+		}
+		seen[poly] = true
+		if len(p.m) != int(p.s) {
+			t.Errorf("dimension %v: expected %v direction numbers, got %v", d+2, p.s, len(p.m))
+		}
+		for i, m := range p.m {
+			if m%2 == 0 || m >= 1<<uint(i+1) {
+				t.Errorf("dimension %v: invalid direction number %v", d+2, m)
+			}
+		}
+	}
+}
+
+// TestSobolKnown tests the first points of the unscrambled sequence.
+func TestSobolKnown(t *testing.T) {
+	want := [][]float64{
+		{0, 0}, {0.5, 0.5}, {0.75, 0.25}, {0.25, 0.75},
+		{0.375, 0.375}, {0.875, 0.875}, {0.625, 0.125}, {0.125, 0.625},
+	}
+	s := newSobol(2)
+	for i, w := range want {
+		if p := s.Point(uint64(i)); !reflect.DeepEqual(p, w) {
+			t.Errorf("point %v is %v, expected %v", i, p, w)
+		}
+	}
+}
+
+// TestSobolNet tests that the first 2^m points of every coordinate are
+// stratified, and that the first two coordinates form a (0,m,2)-net, with
+// and without scrambling.
+func TestSobolNet(t *testing.T) {
+	const m = 10
+	for _, s := range []*Sobol{newSobol(MaxSobolDim), NewSobol(MaxSobolDim, nil)} {
+		points := make([][]float64, 1<<m)
+		for i := range points {
+			points[i] = s.Point(uint64(i))
+		}
+		for d := 0; d < s.Dim(); d++ {
+			xs := make([]float64, len(points))
+			for i, p := range points {
+				xs[i] = p[d]
+			}
+			checkStrata(t, "Sobol", xs, 1<<m)
+		}
+		for k := 0; k <= m; k++ {
+			seen := make(map[[2]int]bool)
+			for _, p := range points {
+				box := [2]int{int(p[0] * float64(int(1)<<k)), int(p[1] * float64(int(1)<<(m-k)))}
+				if seen[box] {
+					t.Fatalf("two points in box %v of size 2^-%v x 2^-%v", box, k, m-k)
+				}
+				seen[box] = true
+			}
+		}
+	}
+}
+
+// TestSobolScrambleUniform tests that each point of a scrambled sequence is
+// uniformly distributed over independent scrambles.
+func TestSobolScrambleUniform(t *testing.T) {
+	const n = 5000
+	for _, i := range []uint64{0, 5, 1 << 20} {
+		xs, ys := make([]float64, n), make([]float64, n)
+		for j := range xs {
+			p := NewSobol(2, nil).Point(i)
+			xs[j], ys[j] = p[0], p[1]
+		}
+		checkUniformKS(t, "Sobol", xs)
+		checkUniformKS(t, "Sobol", ys)
+	}
+}
+
+// TestSobolReproducible tests that a seeded Generator reproduces the same
+// scramble.
+func TestSobolReproducible(t *testing.T) {
+	a := NewSobol(5, fastrand.NewSeededGenerator([]byte("seed")))
+	b := NewSobol(5, fastrand.NewSeededGenerator([]byte("seed")))
+	for i := uint64(0); i < 100; i++ {
+		if p, q := a.Point(i), b.Point(i); !reflect.DeepEqual(p, q) {
+			t.Fatalf("point %v differs: %v and %v", i, p, q)
+		}
+	}
+}
+
+// TestSobolPanics tests that Sobol panics on invalid dimensions and indices.
+func TestSobolPanics(t *testing.T) {
+	for _, dim := range []int{0, MaxSobolDim + 1} {
+		if !panics(func() { NewSobol(dim, nil) }) {
+			t.Errorf("expected panic for dimension %v", dim)
+		}
+	}
+	if !panics(func() { NewSobol(1, nil).Point(1 << 32) }) {
+		t.Error("expected panic for index 2^32")
+	}
+}
+
+// BenchmarkSobol benchmarks drawing a scrambled 10-dimensional point.
+func BenchmarkSobol(b *testing.B) {
+	s := NewSobol(10, nil)
+	for i := 0; i < b.N; i++ {
+		_ = s.Point(uint64(i))
+	}
+}
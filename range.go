@@ -0,0 +1,52 @@
+package fastrand
+
+import (
+	"encoding/binary"
+	"math"
+	"strconv"
+)
+
+// randUint64 returns a uniform random uint64.
+func randUint64() uint64 {
+	var b [8]byte
+	Read(b[:])
+	return binary.LittleEndian.Uint64(b[:])
+}
+
+// Uint64Range returns a uniform random uint64 in [min,max]. Unlike
+// min+Uint64n(max-min), it covers the full range of uint64 without overflow.
+// It panics if min > max.
+func Uint64Range(min, max uint64) uint64 {
+	if min > max {
+		panic("fastrand: invalid range passed to Uint64Range: " + strconv.FormatUint(min, 10) + " > " + strconv.FormatUint(max, 10))
+	}
+	span := max - min
+	if span == math.MaxUint64 {
+		return randUint64()
+	}
+	return min + Uint64n(span+1)
+}
+
+// Int64Range returns a uniform random int64 in [min,max]. Unlike
+// min+Intn(max-min), it covers the full range of int64 without overflow. It
+// panics if min > max.
+func Int64Range(min, max int64) int64 {
+	if min > max {
+		panic("fastrand: invalid range passed to Int64Range: " + strconv.FormatInt(min, 10) + " > " + strconv.FormatInt(max, 10))
+	}
+	// The difference of the two's complement representations is the width
+	// of the range, even when max-min would overflow an int64.
+	span := uint64(max) - uint64(min)
+	if span == math.MaxUint64 {
+		return int64(randUint64())
+	}
+	return int64(uint64(min) + Uint64n(span+1))
+}
+
+// IntRange returns a uniform random int in [min,max]. It panics if min > max.
+func IntRange(min, max int) int {
+	if min > max {
+		panic("fastrand: invalid range passed to IntRange: " + strconv.Itoa(min) + " > " + strconv.Itoa(max))
+	}
+	return int(Int64Range(int64(min), int64(max)))
+}
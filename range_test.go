@@ -0,0 +1,134 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// TestRangePanics tests that the range functions panic if min > max.
+func TestRangePanics(t *testing.T) {
+	if !panics(func() { Uint64Range(1, 0) }) {
+		t.Error("expected panic for min > max")
+	}
+	if !panics(func() { Int64Range(0, -1) }) {
+		t.Error("expected panic for min > max")
+	}
+	if !panics(func() { IntRange(math.MaxInt64, math.MinInt64) }) {
+		t.Error("expected panic for min > max")
+	}
+	if panics(func() { Uint64Range(0, 0) }) {
+		t.Error("did not expect panic for min == max")
+	}
+	if panics(func() { Int64Range(math.MinInt64, math.MaxInt64) }) {
+		t.Error("did not expect panic for full range")
+	}
+}
+
+// TestInt64RangeEdges tests Int64Range on ranges at the edges of the int64
+// domain.
+func TestInt64RangeEdges(t *testing.T) {
+	tests := []struct {
+		min, max int64
+	}{
+		{math.MinInt64, math.MinInt64},
+		{math.MaxInt64, math.MaxInt64},
+		{0, 0},
+		{-1, 0},
+		{-1, 1},
+		{math.MinInt64, math.MinInt64 + 1},
+		{math.MaxInt64 - 1, math.MaxInt64},
+		{math.MinInt64, 0},
+		{-1, math.MaxInt64},
+		{math.MinInt64, math.MaxInt64},
+		{math.MinInt64 + 1, math.MaxInt64},
+		{math.MinInt64, math.MaxInt64 - 1},
+	}
+	for _, test := range tests {
+		for i := 0; i < 1000; i++ {
+			if r := Int64Range(test.min, test.max); r < test.min || r > test.max {
+				t.Fatalf("Int64Range(%v, %v) returned %v", test.min, test.max, r)
+			}
+		}
+	}
+
+	// Both endpoints of small ranges at the edges must be reachable.
+	for _, test := range tests[3:7] {
+		seen := make(map[int64]bool)
+		for i := 0; i < 1000; i++ {
+			seen[Int64Range(test.min, test.max)] = true
+		}
+		if !seen[test.min] || !seen[test.max] {
+			t.Errorf("Int64Range(%v, %v) did not reach both endpoints", test.min, test.max)
+		}
+	}
+
+	// The full range should produce negative and non-negative values equally.
+	var neg int
+	for i := 0; i < 10000; i++ {
+		if Int64Range(math.MinInt64, math.MaxInt64) < 0 {
+			neg++
+		}
+	}
+	if neg < 4500 || neg > 5500 {
+		t.Errorf("expected roughly 5000 negative values, got %v", neg)
+	}
+}
+
+// TestUint64RangeEdges tests Uint64Range on ranges at the edges of the
+// uint64 domain.
+func TestUint64RangeEdges(t *testing.T) {
+	tests := []struct {
+		min, max uint64
+	}{
+		{0, 0},
+		{math.MaxUint64, math.MaxUint64},
+		{0, 1},
+		{math.MaxUint64 - 1, math.MaxUint64},
+		{0, math.MaxUint64},
+		{1, math.MaxUint64},
+		{0, math.MaxUint64 - 1},
+		{math.MaxUint64 / 2, math.MaxUint64/2 + 1},
+	}
+	for _, test := range tests {
+		seen := make(map[uint64]bool)
+		for i := 0; i < 1000; i++ {
+			r := Uint64Range(test.min, test.max)
+			if r < test.min || r > test.max {
+				t.Fatalf("Uint64Range(%v, %v) returned %v", test.min, test.max, r)
+			}
+			seen[r] = true
+		}
+		if test.max-test.min <= 1 && (!seen[test.min] || !seen[test.max]) {
+			t.Errorf("Uint64Range(%v, %v) did not reach both endpoints", test.min, test.max)
+		}
+	}
+}
+
+// TestIntRange tests that IntRange is uniform over a range spanning zero.
+func TestIntRange(t *testing.T) {
+	const iters = 44000
+	var counts [11]int
+	for i := 0; i < iters; i++ {
+		counts[IntRange(-5, 5)+5]++
+	}
+	exp := iters / len(counts)
+	lower, upper := exp-(exp/10), exp+(exp/10)
+	for i, n := range counts {
+		if !(lower < n && n < upper) {
+			t.Errorf("Expected range of %v-%v for value %v, got %v", lower, upper, i-5, n)
+		}
+	}
+
+	for i := 0; i < 1000; i++ {
+		if r := IntRange(math.MinInt64, math.MinInt64+1); r != math.MinInt64 && r != math.MinInt64+1 {
+			t.Fatalf("IntRange returned %v", r)
+		}
+	}
+}
+
+// BenchmarkInt64Range benchmarks the Int64Range function.
+func BenchmarkInt64Range(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = Int64Range(-2e3, 2e3)
+	}
+}
@@ -0,0 +1,113 @@
+package fastrand
+
+import (
+	"math/big"
+	"sort"
+)
+
+// BernoulliRat returns true with probability exactly p. It panics if p is not
+// in [0,1].
+//
+// BernoulliRat compares a uniform random real u in [0,1) against p, and
+// returns u < p. The bits of u are generated lazily, 64 at a time, and are
+// compared against the binary expansion of p, which is computed by long
+// division. Since the comparison is almost always decided by the first word,
+// the cost is dominated by a single draw.
+func BernoulliRat(p *big.Rat) bool {
+	return (*Generator)(nil).BernoulliRat(p)
+}
+
+// CategoricalRat returns a random index i with probability exactly
+// weights[i]/sum(weights). It panics if weights is empty, if any weight is
+// negative, or if all weights are zero.
+//
+// CategoricalRat locates a lazily generated uniform random real in [0,1)
+// among the cumulative sums of the normalized weights, drawing only as many
+// bits as are needed to determine which interval it falls in.
+func CategoricalRat(weights []*big.Rat) int {
+	return (*Generator)(nil).CategoricalRat(weights)
+}
+
+// BernoulliRat returns true with probability exactly p. It panics if p is not
+// in [0,1].
+func (g *Generator) BernoulliRat(p *big.Rat) bool {
+	if p.Sign() < 0 || p.Cmp(big.NewRat(1, 1)) > 0 {
+		panic("fastrand: probability passed to BernoulliRat is not in [0,1]")
+	}
+	return bernoulliRat(p, 64, g.Uint64)
+}
+
+// CategoricalRat returns a random index i with probability exactly
+// weights[i]/sum(weights). It panics if weights is empty, if any weight is
+// negative, or if all weights are zero.
+func (g *Generator) CategoricalRat(weights []*big.Rat) int {
+	if len(weights) == 0 {
+		panic("fastrand: CategoricalRat called with no weights")
+	}
+	// cum[i] is the sum of the weights before i.
+	cum := make([]*big.Rat, len(weights)+1)
+	cum[0] = new(big.Rat)
+	for i, w := range weights {
+		if w.Sign() < 0 {
+			panic("fastrand: negative weight passed to CategoricalRat")
+		}
+		cum[i+1] = new(big.Rat).Add(cum[i], w)
+	}
+	total := cum[len(weights)]
+	if total.Sign() == 0 {
+		panic("fastrand: total weight passed to CategoricalRat is 0")
+	}
+	for _, c := range cum {
+		c.Quo(c, total)
+	}
+	return searchUniform(cum, 64, g.Uint64)
+}
+
+// bernoulliRat returns whether a uniform random real u in [0,1) is less than
+// p, for p in [0,1]. Each call to next supplies the next w bits of u, most
+// significant first, in the low bits of its result.
+func bernoulliRat(p *big.Rat, w uint, next func() uint64) bool {
+	// Each iteration computes the next w bits of p as the quotient of
+	// r*2^w and the denominator of p, leaving the remainder in r.
+	r := new(big.Int).Set(p.Num())
+	d := p.Denom()
+	digit := new(big.Int)
+	if r.Cmp(d) >= 0 {
+		return true
+	}
+	for r.Sign() != 0 {
+		r.Lsh(r, w)
+		digit.QuoRem(r, d, r)
+		switch u := next(); {
+		case u < digit.Uint64():
+			return true
+		case u > digit.Uint64():
+			return false
+		}
+	}
+	// The remaining bits of p are zero, so u >= p.
+	return false
+}
+
+// searchUniform returns the index i such that cum[i] <= u < cum[i+1], where
+// u is a uniform random real in [0,1) and cum is non-decreasing with
+// cum[0] = 0 and cum[len(cum)-1] = 1. Each call to next supplies the next w
+// bits of u, most significant first, in the low bits of its result.
+func searchUniform(cum []*big.Rat, w uint, next func() uint64) int {
+	// After each draw, u is known to lie in [num/den, (num+1)/den).
+	num, den := new(big.Int), big.NewInt(1)
+	one := big.NewInt(1)
+	lo, hi := new(big.Rat), new(big.Rat)
+	for {
+		num.Lsh(num, w).Or(num, new(big.Int).SetUint64(next()))
+		den.Lsh(den, w)
+		lo.SetFrac(num, den)
+		hi.SetFrac(new(big.Int).Add(num, one), den)
+		// i is the last interval that starts at or before lo. Since lo < 1,
+		// it is not the last boundary.
+		i := sort.Search(len(cum), func(j int) bool { return cum[j].Cmp(lo) > 0 }) - 1
+		if hi.Cmp(cum[i+1]) <= 0 {
+			return i
+		}
+	}
+}
@@ -0,0 +1,181 @@
+package fastrand
+
+import (
+	"math/big"
+	"testing"
+)
+
+// errExhausted is panicked by the sources built by enumerateBits when fn asks
+// for more than the enumerated bits.
+type errExhausted struct{}
+
+// enumerateBits calls fn once for each string of l bits, supplying the bits w
+// at a time through next. It returns the number of strings for which fn
+// returned each outcome; strings for which fn needed more than l bits are not
+// counted.
+func enumerateBits(l, w uint, fn func(next func() uint64) int) map[int]int64 {
+	counts := make(map[int]int64)
+	for k := uint64(0); k < 1<<l; k++ {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					if _, ok := r.(errExhausted); !ok {
+						panic(r)
+					}
+				}
+			}()
+			left := l
+			next := func() uint64 {
+				if left < w {
+					panic(errExhausted{})
+				}
+				left -= w
+				return (k >> left) & (1<<w - 1)
+			}
+			counts[fn(next)]++
+		}()
+	}
+	return counts
+}
+
+// decidedCount returns the number of strings of l bits that, read as the
+// leading bits of a uniform random real u, determine that u lies in [a,b).
+// This is the number of integers k with a <= k/2^l and (k+1)/2^l <= b.
+func decidedCount(a, b *big.Rat, l uint) int64 {
+	scale := new(big.Rat).SetInt(new(big.Int).Lsh(big.NewInt(1), l))
+	lo := new(big.Rat).Mul(a, scale)
+	hi := new(big.Rat).Mul(b, scale)
+	// ceil(lo) and floor(hi).
+	first := new(big.Int).Neg(new(big.Int).Div(new(big.Int).Neg(lo.Num()), lo.Denom()))
+	last := new(big.Int).Div(hi.Num(), hi.Denom())
+	if n := last.Int64() - first.Int64(); n > 0 {
+		return n
+	}
+	return 0
+}
+
+// TestBernoulliRatEnumerate tests bernoulliRat exhaustively for every
+// probability with a small denominator, checking that every string of bits
+// is decided exactly as the comparison of u against p requires.
+func TestBernoulliRatEnumerate(t *testing.T) {
+	const l = 12
+	zero, one := new(big.Rat), big.NewRat(1, 1)
+	for _, w := range []uint{1, 2, 3, 4} {
+		for d := int64(1); d <= 16; d++ {
+			for n := int64(0); n <= d; n++ {
+				p := big.NewRat(n, d)
+				counts := enumerateBits(l, w, func(next func() uint64) int {
+					if bernoulliRat(p, w, next) {
+						return 1
+					}
+					return 0
+				})
+				if exp := decidedCount(zero, p, l); counts[1] != exp {
+					t.Errorf("p = %v, w = %v: %v strings returned true, expected %v", p, w, counts[1], exp)
+				}
+				if exp := decidedCount(p, one, l); counts[0] != exp {
+					t.Errorf("p = %v, w = %v: %v strings returned false, expected %v", p, w, counts[0], exp)
+				}
+			}
+		}
+	}
+}
+
+// TestCategoricalRatEnumerate tests searchUniform exhaustively for several
+// sets of weights with small denominators.
+func TestCategoricalRatEnumerate(t *testing.T) {
+	const l = 12
+	for _, weights := range [][]*big.Rat{
+		{big.NewRat(1, 1)},
+		{big.NewRat(1, 3), big.NewRat(2, 3)},
+		{big.NewRat(1, 7), big.NewRat(0, 1), big.NewRat(3, 5), big.NewRat(1, 2)},
+		{big.NewRat(0, 1), big.NewRat(1, 6), big.NewRat(1, 6), big.NewRat(1, 6), big.NewRat(0, 1)},
+		{big.NewRat(5, 11), big.NewRat(5, 11), big.NewRat(1, 11)},
+	} {
+		total := new(big.Rat)
+		for _, w := range weights {
+			total.Add(total, w)
+		}
+		cum := make([]*big.Rat, len(weights)+1)
+		cum[0] = new(big.Rat)
+		for i, w := range weights {
+			cum[i+1] = new(big.Rat).Add(cum[i], new(big.Rat).Quo(w, total))
+		}
+		for _, w := range []uint{1, 3, 4} {
+			counts := enumerateBits(l, w, func(next func() uint64) int {
+				return searchUniform(cum, w, next)
+			})
+			for i := range weights {
+				if exp := decidedCount(cum[i], cum[i+1], l); counts[i] != exp {
+					t.Errorf("weights %v, w = %v: index %v returned by %v strings, expected %v", weights, w, i, counts[i], exp)
+				}
+			}
+		}
+	}
+}
+
+// TestBernoulliRat tests BernoulliRat with the package's own source.
+func TestBernoulliRat(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		if BernoulliRat(new(big.Rat)) || !BernoulliRat(big.NewRat(1, 1)) {
+			t.Fatal("BernoulliRat is wrong for p in {0, 1}")
+		}
+	}
+	// A probability with a huge denominator, just above 1/2.
+	huge := new(big.Int).Lsh(big.NewInt(1), 200)
+	p := new(big.Rat).SetFrac(new(big.Int).Add(new(big.Int).Rsh(huge, 1), big.NewInt(1)), huge)
+	for _, p := range []*big.Rat{big.NewRat(1, 3), big.NewRat(9, 10), p} {
+		f, _ := p.Float64()
+		checkChiSquare(t, 20000, []float64{1 - f, f}, func() int {
+			if BernoulliRat(p) {
+				return 1
+			}
+			return 0
+		})
+	}
+}
+
+// TestCategoricalRat tests CategoricalRat with the package's own source.
+func TestCategoricalRat(t *testing.T) {
+	weights := []*big.Rat{big.NewRat(1, 2), big.NewRat(0, 1), big.NewRat(1, 3), big.NewRat(7, 6)}
+	probs := []float64{0.25, 0, 1.0 / 6, 7.0 / 12}
+	checkChiSquare(t, 20000, probs, func() int { return CategoricalRat(weights) })
+
+	g := NewGenerator(Reader)
+	checkChiSquare(t, 20000, probs, func() int { return g.CategoricalRat(weights) })
+}
+
+// TestRatPanics tests that BernoulliRat and CategoricalRat panic on invalid
+// arguments.
+func TestRatPanics(t *testing.T) {
+	for _, p := range []*big.Rat{big.NewRat(-1, 3), big.NewRat(4, 3)} {
+		if !panics(func() { BernoulliRat(p) }) {
+			t.Errorf("expected panic for p = %v", p)
+		}
+	}
+	for _, weights := range [][]*big.Rat{
+		nil,
+		{big.NewRat(0, 1), big.NewRat(0, 1)},
+		{big.NewRat(1, 2), big.NewRat(-1, 3)},
+	} {
+		if !panics(func() { CategoricalRat(weights) }) {
+			t.Errorf("expected panic for weights %v", weights)
+		}
+	}
+}
+
+// BenchmarkBernoulliRat benchmarks BernoulliRat with a small denominator.
+func BenchmarkBernoulliRat(b *testing.B) {
+	p := big.NewRat(1, 3)
+	for i := 0; i < b.N; i++ {
+		_ = BernoulliRat(p)
+	}
+}
+
+// BenchmarkCategoricalRat benchmarks CategoricalRat with four weights.
+func BenchmarkCategoricalRat(b *testing.B) {
+	weights := []*big.Rat{big.NewRat(1, 2), big.NewRat(1, 5), big.NewRat(1, 3), big.NewRat(7, 6)}
+	for i := 0; i < b.N; i++ {
+		_ = CategoricalRat(weights)
+	}
+}
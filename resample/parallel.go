@@ -0,0 +1,113 @@
+package resample
+
+import (
+	"encoding/binary"
+	"math"
+	"runtime"
+	"sync"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Substream returns the Generator for iteration i of a computation with the
+// given seed. Its output depends only on seed and i, so a parallel
+// computation that draws iteration i from Substream(seed, i) gives the same
+// results regardless of how iterations are divided among workers.
+func Substream(seed []byte, i uint64) *fastrand.Generator {
+	// The index goes first, at a fixed length, so that distinct (seed, i)
+	// pairs never produce the same input.
+	b := make([]byte, 8+len(seed))
+	binary.LittleEndian.PutUint64(b, i)
+	copy(b[8:], seed)
+	return fastrand.NewSeededGenerator(b)
+}
+
+// Parallel calls fn(i, Substream(seed, i)) for every i in [0,iters), using
+// the given number of worker goroutines, and returns once all calls have
+// finished. If workers <= 0, it uses runtime.GOMAXPROCS(0) workers. If seed
+// is nil, a random seed is used. fn must be safe for concurrent use.
+func Parallel(iters, workers int, seed []byte, fn func(i int, src *fastrand.Generator)) {
+	if workers <= 0 {
+		workers = runtime.GOMAXPROCS(0)
+	}
+	if seed == nil {
+		seed = fastrand.Bytes(32)
+	}
+	var wg sync.WaitGroup
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range next {
+				fn(i, Substream(seed, uint64(i)))
+			}
+		}()
+	}
+	for i := 0; i < iters; i++ {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+}
+
+// A PermutationTest is a Monte Carlo permutation test. Under the null
+// hypothesis, the labels attached to the N observations are exchangeable, so
+// the observed statistic is no more extreme than the statistic computed
+// after randomly permuting the labels.
+type PermutationTest struct {
+	// N is the number of observations.
+	N int
+
+	// Statistic computes the test statistic with the observations relabeled
+	// by perm: observation i is given the label of observation perm[i].
+	// Larger values are more extreme, and NaN counts as extreme. Statistic
+	// is called concurrently, and must not modify perm.
+	Statistic func(perm []int) float64
+
+	// Iterations is the number of random permutations to draw.
+	Iterations int
+
+	// Workers is the number of goroutines to use. If Workers <= 0,
+	// runtime.GOMAXPROCS(0) goroutines are used.
+	Workers int
+
+	// Seed determines the random permutations. Permutation i is drawn from
+	// Substream(Seed, i), so the result depends only on Seed, not on
+	// Workers. If Seed is nil, a random seed is used.
+	Seed []byte
+}
+
+// PValue runs the test and returns its one-sided p-value, (1+k)/(1+n), where
+// n is the number of iterations and k is the number of them whose statistic
+// is at least the observed statistic. Counting the observed labeling as one
+// of the permutations makes the test exact: under the null hypothesis, the
+// p-value is at most α with probability at most α. It panics if N < 0 or
+// Iterations < 0.
+func (pt PermutationTest) PValue() float64 {
+	if pt.N < 0 || pt.Iterations < 0 {
+		panic("fastrand: invalid PermutationTest size")
+	}
+	identity := make([]int, pt.N)
+	for i := range identity {
+		identity[i] = i
+	}
+	observed := pt.Statistic(identity)
+
+	var mu sync.Mutex
+	var extreme int
+	Parallel(pt.Iterations, pt.Workers, pt.Seed, func(_ int, src *fastrand.Generator) {
+		perm := make([]int, pt.N)
+		for i := range perm {
+			j := src.Intn(i + 1)
+			perm[i] = perm[j]
+			perm[j] = i
+		}
+		if s := pt.Statistic(perm); s >= observed || math.IsNaN(s) {
+			mu.Lock()
+			extreme++
+			mu.Unlock()
+		}
+	})
+	return float64(1+extreme) / float64(1+pt.Iterations)
+}
@@ -0,0 +1,120 @@
+package resample
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// TestSubstream tests that substreams depend on both the seed and the index.
+func TestSubstream(t *testing.T) {
+	a := Substream([]byte("seed"), 1).Uint64()
+	if b := Substream([]byte("seed"), 1).Uint64(); a != b {
+		t.Error("same seed and index produced different values")
+	}
+	if b := Substream([]byte("seed"), 2).Uint64(); a == b {
+		t.Error("different indices produced the same value")
+	}
+	if b := Substream([]byte("seee"), 1).Uint64(); a == b {
+		t.Error("different seeds produced the same value")
+	}
+}
+
+// TestParallel tests that Parallel calls fn once for each index, with that
+// index's substream, for any number of workers.
+func TestParallel(t *testing.T) {
+	seed := []byte("seed")
+	for _, workers := range []int{0, 1, 3, 100} {
+		var mu sync.Mutex
+		got := make(map[int]uint64)
+		Parallel(50, workers, seed, func(i int, src *fastrand.Generator) {
+			x := src.Uint64()
+			mu.Lock()
+			defer mu.Unlock()
+			if _, ok := got[i]; ok {
+				t.Errorf("index %v visited twice", i)
+			}
+			got[i] = x
+		})
+		if len(got) != 50 {
+			t.Fatalf("expected 50 calls, got %v", len(got))
+		}
+		for i, x := range got {
+			if x != Substream(seed, uint64(i)).Uint64() {
+				t.Fatalf("index %v did not use its substream", i)
+			}
+		}
+	}
+}
+
+// meanDifference returns a permutation test statistic for the difference
+// between the mean of the first na observations and the mean of the rest.
+func meanDifference(data []float64, na int) func([]int) float64 {
+	return func(perm []int) float64 {
+		var a, b float64
+		for i, j := range perm {
+			if i < na {
+				a += data[j]
+			} else {
+				b += data[j]
+			}
+		}
+		return a/float64(na) - b/float64(len(data)-na)
+	}
+}
+
+// TestPermutationTest tests that a permutation test rejects a clear
+// difference, that its p-values are uniform under the null hypothesis, and
+// that its result depends only on the seed.
+func TestPermutationTest(t *testing.T) {
+	// The first group is much larger than the second.
+	data := []float64{10, 11, 12, 13, 14, 1, 2, 3, 4, 5}
+	pt := PermutationTest{N: len(data), Statistic: meanDifference(data, 5), Iterations: 999}
+	// Only relabelings within the groups are as extreme as the identity,
+	// which is 1 in 252 of the ways to split the data, so about 4 of the 999
+	// permutations should be.
+	if p := pt.PValue(); p > 0.02 {
+		t.Errorf("expected a p-value near 1/252, got %v", p)
+	}
+
+	// Under the null hypothesis, P(p <= 0.2) <= 0.2.
+	var small int
+	const trials = 300
+	for i := 0; i < trials; i++ {
+		null := make([]float64, 10)
+		for j := range null {
+			null[j] = fastrand.Float64()
+		}
+		pt := PermutationTest{N: len(null), Statistic: meanDifference(null, 4), Iterations: 99}
+		if pt.PValue() <= 0.2 {
+			small++
+		}
+	}
+	if small > trials*30/100 || small < trials*10/100 {
+		t.Errorf("%v of %v null p-values were at most 0.2", small, trials)
+	}
+
+	// The result depends only on the seed.
+	pt.Seed = []byte("seed")
+	pt.Workers = 1
+	p1 := pt.PValue()
+	pt.Workers = 7
+	if p2 := pt.PValue(); p1 != p2 {
+		t.Errorf("p-value depends on the number of workers: %v != %v", p1, p2)
+	}
+}
+
+// TestPermutationTestPanics tests that PermutationTest panics on invalid
+// sizes.
+func TestPermutationTestPanics(t *testing.T) {
+	stat := func([]int) float64 { return 0 }
+	for _, pt := range []PermutationTest{
+		{N: -1, Statistic: stat, Iterations: 10},
+		{N: 10, Statistic: stat, Iterations: -1},
+	} {
+		if !panics(func() { pt.PValue() }) {
+			t.Errorf("expected panic for %+v", pt)
+		}
+	}
+}
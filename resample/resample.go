@@ -0,0 +1,72 @@
+// Package resample provides resampling schemes for the bootstrap and for
+// permutation tests.
+//
+// The bootstrap functions return indices into the original data, rather than
+// copies of it, so they work with data of any type. Each takes a
+// *fastrand.Generator; a nil Generator draws from fastrand.Reader.
+package resample
+
+import (
+	"math"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// Bootstrap returns n indices drawn uniformly and independently from [0,n),
+// for resampling a data set of size n with replacement. It panics if n < 0.
+func Bootstrap(n int, src *fastrand.Generator) []int {
+	if n < 0 {
+		panic("fastrand: Bootstrap called with negative size")
+	}
+	idx := make([]int, n)
+	for i := range idx {
+		idx[i] = src.Intn(n)
+	}
+	return idx
+}
+
+// BlockBootstrap returns n indices into a time series of length n, formed by
+// concatenating blocks of block consecutive indices, for resampling data
+// whose observations are dependent over short ranges. Each block starts at a
+// uniform random index, and wraps around from n-1 to 0, so that every index
+// is equally likely to appear; this is the circular block bootstrap of
+// Politis and Romano (1992). The last block is truncated to length n. It
+// panics if n < 0 or block < 1.
+func BlockBootstrap(n, block int, src *fastrand.Generator) []int {
+	if n < 0 || block < 1 {
+		panic("fastrand: invalid BlockBootstrap size")
+	}
+	idx := make([]int, 0, n)
+	for len(idx) < n {
+		start := src.Intn(n)
+		for j := 0; j < block && len(idx) < n; j++ {
+			idx = append(idx, (start+j)%n)
+		}
+	}
+	return idx
+}
+
+// StationaryBootstrap returns n indices into a time series of length n,
+// formed like BlockBootstrap's but with block lengths drawn from the
+// geometric distribution with mean meanBlock. This is the stationary
+// bootstrap of Politis and Romano (1994), whose resampled series are
+// stationary, and which is less sensitive to the choice of block length. It
+// panics if n < 0 or meanBlock < 1.
+func StationaryBootstrap(n int, meanBlock float64, src *fastrand.Generator) []int {
+	if n < 0 || !(meanBlock >= 1) || math.IsInf(meanBlock, 0) {
+		panic("fastrand: invalid StationaryBootstrap size")
+	}
+	idx := make([]int, 0, n)
+	p := 1 / meanBlock
+	var cur int
+	for len(idx) < n {
+		// Each index starts a new block with probability p.
+		if len(idx) == 0 || src.Float64() < p {
+			cur = src.Intn(n)
+		} else {
+			cur = (cur + 1) % n
+		}
+		idx = append(idx, cur)
+	}
+	return idx
+}
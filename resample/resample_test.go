@@ -0,0 +1,135 @@
+package resample
+
+import (
+	"math"
+	"testing"
+)
+
+// chiSquareCritical returns the value that a chi-square statistic with df
+// degrees of freedom exceeds with probability of roughly 1e-5, using the
+// Wilson-Hilferty approximation.
+func chiSquareCritical(df int) float64 {
+	const z = 4.265
+	k := float64(df)
+	x := 1 - 2/(9*k) + z*math.Sqrt(2/(9*k))
+	return k * x * x * x
+}
+
+// checkUniformCounts fails the test if counts are not consistent with a
+// uniform distribution over their categories.
+func checkUniformCounts(t *testing.T, name string, counts []int) {
+	t.Helper()
+	var total int
+	for _, c := range counts {
+		total += c
+	}
+	e := float64(total) / float64(len(counts))
+	var stat float64
+	for _, c := range counts {
+		stat += (float64(c) - e) * (float64(c) - e) / e
+	}
+	if crit := chiSquareCritical(len(counts) - 1); stat > crit {
+		t.Errorf("%v: chi-square statistic %.2f exceeds critical value %.2f; counts %v", name, stat, crit, counts)
+	}
+}
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// TestBootstrap tests that Bootstrap's indices are uniform at each position.
+func TestBootstrap(t *testing.T) {
+	if len(Bootstrap(0, nil)) != 0 {
+		t.Error("expected no indices for n == 0")
+	}
+	const n = 10
+	counts := make([][]int, n)
+	for i := range counts {
+		counts[i] = make([]int, n)
+	}
+	for iter := 0; iter < 10000; iter++ {
+		for pos, i := range Bootstrap(n, nil) {
+			counts[pos][i]++
+		}
+	}
+	for _, c := range counts {
+		checkUniformCounts(t, "Bootstrap", c)
+	}
+}
+
+// TestBlockBootstrap tests that BlockBootstrap is made of consecutive,
+// wrapping blocks, and that every index is equally likely.
+func TestBlockBootstrap(t *testing.T) {
+	const n, block = 23, 5
+	counts := make([]int, n)
+	starts := make([]int, n)
+	for iter := 0; iter < 5000; iter++ {
+		idx := BlockBootstrap(n, block, nil)
+		if len(idx) != n {
+			t.Fatalf("expected %v indices, got %v", n, len(idx))
+		}
+		for pos, i := range idx {
+			if pos%block != 0 && i != (idx[pos-1]+1)%n {
+				t.Fatalf("block is not consecutive: %v", idx)
+			}
+			counts[i]++
+		}
+		starts[idx[0]]++
+	}
+	checkUniformCounts(t, "BlockBootstrap", counts)
+	checkUniformCounts(t, "BlockBootstrap starts", starts)
+	if len(BlockBootstrap(0, 3, nil)) != 0 {
+		t.Error("expected no indices for n == 0")
+	}
+}
+
+// TestStationaryBootstrap tests that StationaryBootstrap's blocks have the
+// requested mean length, and that every index is equally likely.
+func TestStationaryBootstrap(t *testing.T) {
+	const n, mean = 1000, 8.0
+	counts := make([]int, 20)
+	var breaks, steps int
+	for iter := 0; iter < 200; iter++ {
+		idx := StationaryBootstrap(n, mean, nil)
+		for pos, i := range idx {
+			counts[i%len(counts)]++
+			if pos > 0 {
+				steps++
+				if i != (idx[pos-1]+1)%n {
+					breaks++
+				}
+			}
+		}
+	}
+	checkUniformCounts(t, "StationaryBootstrap", counts)
+	// A new block starts with probability 1/mean, and lands on the next
+	// index anyway with probability 1/n.
+	rate := float64(breaks) / float64(steps)
+	want := 1 / mean * (1 - 1.0/n)
+	if sd := math.Sqrt(want * (1 - want) / float64(steps)); math.Abs(rate-want) > 5*sd {
+		t.Errorf("blocks break with frequency %v, expected %v", rate, want)
+	}
+}
+
+// TestBootstrapPanics tests that the bootstrap functions panic on invalid
+// sizes.
+func TestBootstrapPanics(t *testing.T) {
+	for name, fn := range map[string]func(){
+		"Bootstrap(-1)":                 func() { Bootstrap(-1, nil) },
+		"BlockBootstrap(-1, 1)":         func() { BlockBootstrap(-1, 1, nil) },
+		"BlockBootstrap(10, 0)":         func() { BlockBootstrap(10, 0, nil) },
+		"StationaryBootstrap(-1, 2)":    func() { StationaryBootstrap(-1, 2, nil) },
+		"StationaryBootstrap(10, 0.5)":  func() { StationaryBootstrap(10, 0.5, nil) },
+		"StationaryBootstrap(10, NaN)":  func() { StationaryBootstrap(10, math.NaN(), nil) },
+		"StationaryBootstrap(10, +Inf)": func() { StationaryBootstrap(10, math.Inf(1), nil) },
+	} {
+		if !panics(fn) {
+			t.Errorf("expected panic for %v", name)
+		}
+	}
+}
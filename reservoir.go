@@ -0,0 +1,144 @@
+package fastrand
+
+import (
+	"container/heap"
+	"math"
+	"strconv"
+)
+
+// A Reservoir maintains a uniform random sample of k items from a stream of
+// unknown length, using O(k) memory. It is not safe for concurrent use.
+//
+// Reservoir uses Li's Algorithm L, which computes how many items to skip
+// before the next item enters the sample, so that random numbers are drawn
+// only O(k log(n/k)) times for a stream of n items.
+type Reservoir[T any] struct {
+	items []T
+	k     int
+	count int     // number of items added
+	logW  float64 // logarithm of Algorithm L's W
+	next  int     // number of items added when the next item enters, or -1
+}
+
+// NewReservoir returns a Reservoir that samples k items. It panics if k < 0.
+func NewReservoir[T any](k int) *Reservoir[T] {
+	if k < 0 {
+		panic("fastrand: invalid reservoir size " + strconv.Itoa(k))
+	}
+	return &Reservoir[T]{
+		items: make([]T, 0, k),
+		k:     k,
+	}
+}
+
+// nextEntry returns the number of items that will have been added when the
+// next item enters a sample, given the number added so far and the logarithm
+// of Algorithm L's W. If that number is too large for an int, it returns -1,
+// and no further item enters the sample. Clamping instead would force an
+// item into the sample at the clamped position.
+func nextEntry(count int, logW float64) int {
+	s := math.Floor(logUniform() / math.Log1p(-math.Exp(logW)))
+	if !(s < float64(math.MaxInt-count)/2) {
+		return -1
+	}
+	return count + int(s) + 1
+}
+
+// Add offers item to the reservoir.
+func (r *Reservoir[T]) Add(item T) {
+	r.count++
+	switch {
+	case r.k == 0:
+	case len(r.items) < r.k:
+		r.items = append(r.items, item)
+		if len(r.items) == r.k {
+			r.logW = logUniform() / float64(r.k)
+			r.next = nextEntry(r.count, r.logW)
+		}
+	case r.count == r.next:
+		r.items[Intn(r.k)] = item
+		r.logW += logUniform() / float64(r.k)
+		r.next = nextEntry(r.count, r.logW)
+	}
+}
+
+// Count returns the number of items that have been added to the reservoir.
+func (r *Reservoir[T]) Count() int {
+	return r.count
+}
+
+// Sample returns the current sample. Each subset of min(k, Count()) of the
+// added items is equally likely. The order of the sample is unspecified.
+func (r *Reservoir[T]) Sample() []T {
+	return append([]T(nil), r.items...)
+}
+
+// A WeightedReservoir maintains a weighted random sample of k items from a
+// stream of unknown length, using O(k) memory. The sample has the same
+// distribution as one produced by WeightedSample. It is not safe for
+// concurrent use.
+//
+// WeightedReservoir uses the A-ExpJ method of Efraimidis and Spirakis, which
+// draws random numbers only when an item enters the sample, rather than for
+// every item.
+type WeightedReservoir[T any] struct {
+	items []T
+	keys  keyHeap // indexes into items
+	k     int
+	jump  float64 // remaining weight to skip before the next item enters
+}
+
+// NewWeightedReservoir returns a WeightedReservoir that samples k items. It
+// panics if k < 0.
+func NewWeightedReservoir[T any](k int) *WeightedReservoir[T] {
+	if k < 0 {
+		panic("fastrand: invalid reservoir size " + strconv.Itoa(k))
+	}
+	return &WeightedReservoir[T]{
+		items: make([]T, 0, k),
+		keys:  make(keyHeap, 0, k),
+		k:     k,
+	}
+}
+
+// Add offers item to the reservoir with the given weight. Items with a weight
+// of zero never enter the sample. It panics if weight is negative or not
+// finite.
+func (r *WeightedReservoir[T]) Add(item T, weight float64) {
+	checkWeight(weight)
+	switch {
+	case weight == 0 || r.k == 0:
+	case len(r.items) < r.k:
+		heap.Push(&r.keys, keyedIndex{len(r.items), logUniform() / weight})
+		r.items = append(r.items, item)
+		if len(r.items) == r.k {
+			r.jump = logUniform() / r.keys[0].key
+		}
+	default:
+		r.jump -= weight
+		if r.jump > 0 {
+			return
+		}
+		// The item replaces the one with the smallest key. Its key is drawn
+		// from the portion of the distribution that exceeds that key.
+		tw := math.Exp(weight * r.keys[0].key)
+		slot := r.keys[0].index
+		r.items[slot] = item
+		r.keys[0] = keyedIndex{slot, math.Log(tw+(1-tw)*(1-Float64())) / weight}
+		heap.Fix(&r.keys, 0)
+		r.jump = logUniform() / r.keys[0].key
+	}
+}
+
+// Sample returns the current sample, containing min(k, n) items where n is
+// the number of items added with positive weight. The items are ordered as
+// if they had been drawn one at a time, in proportion to their weights.
+func (r *WeightedReservoir[T]) Sample() []T {
+	out := make([]T, len(r.items))
+	// indices sorts its receiver, which would break the heap invariant.
+	keys := append(keyHeap(nil), r.keys...)
+	for i, slot := range keys.indices() {
+		out[i] = r.items[slot]
+	}
+	return out
+}
@@ -0,0 +1,152 @@
+package fastrand
+
+import (
+	"math"
+	"math/bits"
+	"testing"
+)
+
+// TestReservoirPanics tests that the reservoir constructors panic if k < 0.
+func TestReservoirPanics(t *testing.T) {
+	if !panics(func() { NewReservoir[int](-1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if !panics(func() { NewWeightedReservoir[int](-1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if !panics(func() { NewWeightedReservoir[int](1).Add(0, -1) }) {
+		t.Error("expected panic for negative weight")
+	}
+}
+
+// TestReservoirShort tests reservoirs that receive fewer than k items.
+func TestReservoirShort(t *testing.T) {
+	r := NewReservoir[int](5)
+	for i := 0; i < 3; i++ {
+		r.Add(i)
+	}
+	if s := r.Sample(); len(s) != 3 || r.Count() != 3 {
+		t.Errorf("expected all 3 items, got %v", s)
+	}
+	r0 := NewReservoir[int](0)
+	r0.Add(1)
+	if s := r0.Sample(); len(s) != 0 {
+		t.Errorf("expected empty sample, got %v", s)
+	}
+
+	wr := NewWeightedReservoir[string](3)
+	wr.Add("a", 1)
+	wr.Add("b", 0)
+	wr.Add("c", 2)
+	if s := wr.Sample(); len(s) != 2 {
+		t.Errorf("expected the 2 positively weighted items, got %v", s)
+	}
+}
+
+// TestReservoir tests that every subset of 3 out of 8 items is equally likely
+// to be sampled.
+func TestReservoir(t *testing.T) {
+	const n, k = 8, 3
+	// Map each subset, as a bitmask, to a category.
+	category := make(map[int]int)
+	for mask := 0; mask < 1<<n; mask++ {
+		if bits.OnesCount(uint(mask)) == k {
+			category[mask] = len(category)
+		}
+	}
+	probs := make([]float64, len(category))
+	for i := range probs {
+		probs[i] = 1 / float64(len(probs))
+	}
+	checkChiSquare(t, 56000, probs, func() int {
+		r := NewReservoir[int](k)
+		for i := 0; i < n; i++ {
+			r.Add(i)
+		}
+		mask := 0
+		for _, item := range r.Sample() {
+			mask |= 1 << uint(item)
+		}
+		return category[mask]
+	})
+}
+
+// TestReservoirLong tests that items from every part of a long stream are
+// sampled equally often, which exercises Algorithm L's skipping.
+func TestReservoirLong(t *testing.T) {
+	const n, k, buckets, iters = 10000, 10, 10, 1000
+	counts := make([]int, buckets)
+	for i := 0; i < iters; i++ {
+		r := NewReservoir[int](k)
+		for j := 0; j < n; j++ {
+			r.Add(j)
+		}
+		for _, item := range r.Sample() {
+			counts[item*buckets/n]++
+		}
+	}
+	exp := float64(iters * k / buckets)
+	for i, c := range counts {
+		if math.Abs(float64(c)-exp) > 5*math.Sqrt(exp) {
+			t.Errorf("bucket %v sampled %v times, expected %v", i, c, exp)
+		}
+	}
+}
+
+// TestReservoirNextEntry tests that long skips are not clamped, which would
+// force an item into the sample, and that skips too long for an int stop
+// further items from entering.
+func TestReservoirNextEntry(t *testing.T) {
+	logW := math.Log(1e-12)
+	if math.MaxInt > math.MaxInt32 {
+		// Skips are about 10^12 items long.
+		var long int
+		for i := 0; i < 100; i++ {
+			if nextEntry(0, logW) > math.MaxInt32 {
+				long++
+			}
+		}
+		if long < 90 {
+			t.Errorf("only %v of 100 skips were longer than MaxInt32", long)
+		}
+	}
+	if e := nextEntry(math.MaxInt-10, logW); e != -1 {
+		t.Errorf("expected no entry near MaxInt, got %v", e)
+	}
+	if e := nextEntry(10, -1e6); e != -1 {
+		t.Errorf("expected no entry for tiny W, got %v", e)
+	}
+
+	r := NewReservoir[int](1)
+	r.Add(0)
+	r.next = -1
+	for i := 1; i < 1000; i++ {
+		r.Add(i)
+	}
+	if s := r.Sample(); len(s) != 1 || s[0] != 0 {
+		t.Errorf("item entered a closed reservoir: %v", s)
+	}
+}
+
+// TestWeightedReservoir tests the inclusion probabilities of
+// WeightedReservoir.
+func TestWeightedReservoir(t *testing.T) {
+	weights := []float64{4, 1, 0, 3, 2}
+	checkInclusion(t, 100000, weights, func() []int {
+		r := NewWeightedReservoir[int](2)
+		for i, w := range weights {
+			r.Add(i, w)
+		}
+		return r.Sample()
+	})
+}
+
+// BenchmarkReservoir benchmarks sampling from a long stream.
+func BenchmarkReservoir(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		r := NewReservoir[int](8)
+		for j := 0; j < 1e4; j++ {
+			r.Add(j)
+		}
+	}
+}
@@ -0,0 +1,175 @@
+package fastrand
+
+import (
+	"math"
+	"sort"
+	"strconv"
+)
+
+// maxVitterRange is the largest range for which SampleIntsSorted uses
+// Algorithm D. Above it, float64 cannot represent every value in the range,
+// and Algorithm D's floating-point arithmetic would no longer be uniform.
+const maxVitterRange = 1 << 53
+
+// SampleInts returns k distinct ints in [0,n), chosen uniformly at random. It
+// uses Robert Floyd's algorithm, which draws exactly k random numbers and
+// uses O(k) memory regardless of n. The order of the result is not random;
+// use Shuffle if a random order is needed. It panics if k < 0 or k > n.
+func SampleInts(n, k int) []int {
+	if k < 0 || k > n {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k) + " for range of size " + strconv.Itoa(n))
+	}
+	seen := make(map[int]struct{}, k)
+	out := make([]int, 0, k)
+	for j := n - k; j < n; j++ {
+		t := Intn(j + 1)
+		if _, ok := seen[t]; ok {
+			t = j
+		}
+		seen[t] = struct{}{}
+		out = append(out, t)
+	}
+	return out
+}
+
+// SampleIntsSorted returns an iterator over k distinct ints in [0,n), chosen
+// uniformly at random and yielded in increasing order. It uses O(1) memory,
+// and each iteration produces a new sample. The iterator has the underlying
+// type of iter.Seq[int], so it can be ranged over with Go 1.23 or later, or
+// called directly. It panics if k < 0 or k > n.
+//
+// SampleIntsSorted uses Jeffrey Vitter's Algorithm D, "An Efficient
+// Algorithm for Sequential Random Sampling" (1987), which draws O(k) random
+// numbers by computing how many values to skip between those that are
+// selected. When the remaining range is not much larger than the remaining
+// sample, it falls back to the simpler Algorithm A. Both do their arithmetic
+// in float64, so for n > 2^53, SampleIntsSorted instead sorts the output of
+// SampleInts, which takes O(k) memory.
+func SampleIntsSorted(n, k int) func(yield func(int) bool) {
+	if k < 0 || k > n {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k) + " for range of size " + strconv.Itoa(n))
+	}
+	return func(yield func(int) bool) {
+		switch {
+		case k == 0:
+		case uint64(n) > maxVitterRange:
+			s := SampleInts(n, k)
+			sort.Ints(s)
+			for _, x := range s {
+				if !yield(x) {
+					return
+				}
+			}
+		default:
+			vitterD(n, k, yield)
+		}
+	}
+}
+
+// uniformOpen returns a uniform random float64 in (0,1).
+func uniformOpen() float64 {
+	for {
+		if u := Float64(); u > 0 {
+			return u
+		}
+	}
+}
+
+// vitterD runs Vitter's Algorithm D, selecting k of n values and passing them
+// to yield in increasing order. It stops early if yield returns false. k must
+// be positive.
+func vitterD(n, k int, yield func(int) bool) {
+	// alpha controls when to switch to Algorithm A; Vitter recommends 13.
+	const alphaInv = 13
+	N, remaining := float64(n), k
+	current := -1
+	kInv := 1 / float64(remaining)
+	vPrime := math.Exp(math.Log(uniformOpen()) * kInv)
+	qu1 := N - float64(remaining) + 1
+	threshold := alphaInv * remaining
+
+	for remaining > 1 && threshold < int(N) {
+		kMin1Inv := 1 / float64(remaining-1)
+		var s float64
+		for {
+			// Generate a candidate skip s from the approximating
+			// distribution.
+			var x float64
+			for {
+				x = N * (1 - vPrime)
+				s = math.Floor(x)
+				if s < qu1 {
+					break
+				}
+				vPrime = math.Exp(math.Log(uniformOpen()) * kInv)
+			}
+			u := uniformOpen()
+			y1 := math.Exp(math.Log(u*N/qu1) * kMin1Inv)
+			vPrime = y1 * (1 - x/N) * (qu1 / (qu1 - s))
+			if vPrime <= 1 {
+				// Accepted by the fast squeeze test.
+				break
+			}
+
+			// Fall back to the exact acceptance test.
+			y2, top := 1.0, N-1
+			var bottom, limit float64
+			if float64(remaining-1) > s {
+				bottom, limit = N-float64(remaining), N-s
+			} else {
+				bottom, limit = N-s-1, qu1
+			}
+			for t := N - 1; t >= limit; t-- {
+				y2 = y2 * top / bottom
+				top--
+				bottom--
+			}
+			if N/(N-x) >= y1*math.Exp(math.Log(y2)*kMin1Inv) {
+				vPrime = math.Exp(math.Log(uniformOpen()) * kMin1Inv)
+				break
+			}
+			vPrime = math.Exp(math.Log(uniformOpen()) * kInv)
+		}
+
+		current += int(s) + 1
+		if !yield(current) {
+			return
+		}
+		N -= s + 1
+		remaining--
+		kInv = kMin1Inv
+		qu1 -= s
+		threshold -= alphaInv
+	}
+
+	if remaining > 1 {
+		vitterA(int(N), remaining, current, yield)
+		return
+	}
+	current += int(math.Floor(N*vPrime)) + 1
+	yield(current)
+}
+
+// vitterA runs Vitter's Algorithm A, selecting k of the n values following
+// current and passing them to yield in increasing order.
+func vitterA(n, k, current int, yield func(int) bool) {
+	top, N := float64(n-k), float64(n)
+	for ; k >= 2; k-- {
+		v := uniformOpen()
+		s := 0
+		quot := top / N
+		for quot > v {
+			s++
+			top--
+			N--
+			quot = quot * top / N
+		}
+		current += s + 1
+		if !yield(current) {
+			return
+		}
+		N--
+	}
+	current += int(math.Floor(N*Float64())) + 1
+	yield(current)
+}
@@ -0,0 +1,167 @@
+package fastrand
+
+import (
+	"math"
+	"sort"
+	"testing"
+)
+
+// collect returns the values yielded by seq.
+func collect(seq func(yield func(int) bool)) []int {
+	var s []int
+	seq(func(x int) bool {
+		s = append(s, x)
+		return true
+	})
+	return s
+}
+
+// TestSampleIntsPanics tests that SampleInts and SampleIntsSorted panic on
+// invalid sample sizes.
+func TestSampleIntsPanics(t *testing.T) {
+	if !panics(func() { SampleInts(5, 6) }) {
+		t.Error("expected panic for k > n")
+	}
+	if !panics(func() { SampleInts(5, -1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if !panics(func() { SampleIntsSorted(5, 6) }) {
+		t.Error("expected panic for k > n")
+	}
+	if !panics(func() { SampleIntsSorted(5, -1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if s := SampleInts(0, 0); len(s) != 0 {
+		t.Errorf("expected empty sample, got %v", s)
+	}
+	if s := collect(SampleIntsSorted(5, 0)); len(s) != 0 {
+		t.Errorf("expected empty sample, got %v", s)
+	}
+}
+
+// pairIndex maps a pair of distinct ints in [0,n) to a unique index in
+// [0,n*(n-1)/2).
+func pairIndex(a, b, n int) int {
+	if a > b {
+		a, b = b, a
+	}
+	return a*n - a*(a+1)/2 + (b - a - 1)
+}
+
+// uniformProbs returns a slice of n equal probabilities.
+func uniformProbs(n int) []float64 {
+	probs := make([]float64, n)
+	for i := range probs {
+		probs[i] = 1 / float64(n)
+	}
+	return probs
+}
+
+// TestSampleInts tests that every pair of distinct ints is equally likely to
+// be sampled by SampleInts.
+func TestSampleInts(t *testing.T) {
+	const n = 10
+	checkChiSquare(t, 45000, uniformProbs(n*(n-1)/2), func() int {
+		s := SampleInts(n, 2)
+		if s[0] == s[1] || s[0] >= n || s[1] >= n {
+			t.Fatalf("invalid sample %v", s)
+		}
+		return pairIndex(s[0], s[1], n)
+	})
+
+	// A full sample must be a permutation.
+	s := SampleInts(100, 100)
+	sort.Ints(s)
+	for i, x := range s {
+		if x != i {
+			t.Fatalf("full sample is not a permutation: %v", s)
+		}
+	}
+
+	// Huge ranges must be supported.
+	for _, x := range SampleInts(math.MaxInt64, 10) {
+		if x < 0 {
+			t.Fatalf("got negative value %v", x)
+		}
+	}
+}
+
+// TestSampleIntsSorted tests that SampleIntsSorted yields increasing values,
+// and that every pair is equally likely, both when Algorithm D is used and
+// when it falls back to Algorithm A.
+func TestSampleIntsSorted(t *testing.T) {
+	for _, n := range []int{10, 50} {
+		checkChiSquare(t, 40*n*(n-1)/2, uniformProbs(n*(n-1)/2), func() int {
+			s := collect(SampleIntsSorted(n, 2))
+			if len(s) != 2 || s[0] >= s[1] || s[0] < 0 || s[1] >= n {
+				t.Fatalf("invalid sample %v", s)
+			}
+			return pairIndex(s[0], s[1], n)
+		})
+	}
+
+	// With many values selected from a large range, each part of the range
+	// should be selected equally often.
+	const n, k, buckets, iters = 100000, 100, 10, 200
+	counts := make([]int, buckets)
+	for i := 0; i < iters; i++ {
+		prev, count := -1, 0
+		SampleIntsSorted(n, k)(func(x int) bool {
+			if x <= prev || x >= n {
+				t.Fatalf("value %v out of order or out of range", x)
+			}
+			prev = x
+			count++
+			counts[x*buckets/n]++
+			return true
+		})
+		if count != k {
+			t.Fatalf("expected %v values, got %v", k, count)
+		}
+	}
+	exp := float64(iters * k / buckets)
+	for i, c := range counts {
+		if math.Abs(float64(c)-exp) > 5*math.Sqrt(exp) {
+			t.Errorf("bucket %v sampled %v times, expected %v", i, c, exp)
+		}
+	}
+
+	// Ranges too large for float64 must still reach every value: with
+	// Algorithm D's arithmetic, the low bits of the values would be zero.
+	if math.MaxInt > maxVitterRange {
+		checkChiSquare(t, 16000, uniformProbs(16), func() int {
+			s := collect(SampleIntsSorted(math.MaxInt, 3))
+			if len(s) != 3 || s[0] >= s[1] || s[1] >= s[2] || s[0] < 0 {
+				t.Fatalf("invalid sample %v", s)
+			}
+			return s[Intn(3)] % 16
+		})
+	}
+
+	// A full sample must include every value, and iteration can stop early.
+	if s := collect(SampleIntsSorted(20, 20)); len(s) != 20 || s[0] != 0 || s[19] != 19 {
+		t.Errorf("full sample is not the whole range: %v", s)
+	}
+	var yielded int
+	SampleIntsSorted(1000, 10)(func(int) bool {
+		yielded++
+		return false
+	})
+	if yielded != 1 {
+		t.Errorf("iterator continued after yield returned false")
+	}
+}
+
+// BenchmarkSampleInts benchmarks SampleInts on a huge range.
+func BenchmarkSampleInts(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		SampleInts(1e12, 100)
+	}
+}
+
+// BenchmarkSampleIntsSorted benchmarks SampleIntsSorted on a huge range.
+func BenchmarkSampleIntsSorted(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		SampleIntsSorted(1e12, 100)(func(int) bool { return true })
+	}
+}
@@ -0,0 +1,47 @@
+package fastrand
+
+import (
+	"strconv"
+)
+
+// Shuffle randomly permutes the elements of s in place. Like Perm, it uses
+// the Fisher-Yates algorithm, so every permutation is equally likely.
+func Shuffle[T any](s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := Intn(i + 1)
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Choice returns a uniform random element of s. It panics if s is empty.
+func Choice[T any](s []T) T {
+	if len(s) == 0 {
+		panic("fastrand: Choice called on empty slice")
+	}
+	return s[Intn(len(s))]
+}
+
+// Sample returns k distinct elements of s, chosen uniformly at random and in
+// random order. It does not modify or copy s, and uses O(k) memory. It panics
+// if k < 0 or k > len(s).
+func Sample[T any](s []T, k int) []T {
+	if k < 0 || k > len(s) {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k) + " for slice of length " + strconv.Itoa(len(s)))
+	}
+	// Run the first k steps of a Fisher-Yates shuffle over the indices of s,
+	// recording only the indices that have been displaced.
+	swapped := make(map[int]int, k)
+	index := func(i int) int {
+		if j, ok := swapped[i]; ok {
+			return j
+		}
+		return i
+	}
+	out := make([]T, k)
+	for i := range out {
+		j := i + Intn(len(s)-i)
+		out[i] = s[index(j)]
+		swapped[j] = index(i)
+	}
+	return out
+}
@@ -0,0 +1,120 @@
+package fastrand
+
+import (
+	"testing"
+)
+
+// TestShuffle tests that Shuffle produces every permutation equally often,
+// in the same manner as TestPerm.
+func TestShuffle(t *testing.T) {
+	chars := "abcde" // string to be permuted
+	permCount := make(map[string]int)
+	for i := 0; i < 12000; i++ {
+		s := []byte(chars)
+		Shuffle(s)
+		permCount[string(s)]++
+	}
+
+	// we should have seen each permutation approx. 100 times
+	if len(permCount) != 120 {
+		t.Errorf("saw %v distinct permutations, expected 120", len(permCount))
+	}
+	for p, n := range permCount {
+		if n < 50 || n > 150 {
+			t.Errorf("saw permutation %v times: %v", n, p)
+		}
+	}
+
+	// Shuffling empty and single-element slices should be a no-op.
+	Shuffle([]int(nil))
+	one := []int{7}
+	Shuffle(one)
+	if one[0] != 7 {
+		t.Error("Shuffle modified a single-element slice")
+	}
+}
+
+// TestChoice tests the Choice function.
+func TestChoice(t *testing.T) {
+	if !panics(func() { Choice([]int{}) }) {
+		t.Error("expected panic for empty slice")
+	}
+
+	const iters = 40000
+	chars := []byte("abcdefghij")
+	counts := make(map[byte]int)
+	for i := 0; i < iters; i++ {
+		counts[Choice(chars)]++
+	}
+	exp := iters / len(chars)
+	lower, upper := exp-(exp/10), exp+(exp/10)
+	for _, c := range chars {
+		if n := counts[c]; !(lower < n && n < upper) {
+			t.Errorf("Expected range of %v-%v for %c, got %v", lower, upper, c, n)
+		}
+	}
+}
+
+// TestSample tests that Sample produces every ordered k-sample equally often,
+// without modifying its input.
+func TestSample(t *testing.T) {
+	if !panics(func() { Sample([]int{1, 2}, 3) }) {
+		t.Error("expected panic for k > len(s)")
+	}
+	if !panics(func() { Sample([]int{1, 2}, -1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if s := Sample([]int{1, 2}, 0); len(s) != 0 {
+		t.Errorf("expected empty sample, got %v", s)
+	}
+
+	// There are 5*4*3 = 60 ordered samples of 3 from 5 elements.
+	chars := []byte("abcde")
+	sampleCount := make(map[string]int)
+	for i := 0; i < 6000; i++ {
+		s := Sample(chars, 3)
+		if s[0] == s[1] || s[0] == s[2] || s[1] == s[2] {
+			t.Fatalf("sample contains duplicates: %s", s)
+		}
+		sampleCount[string(s)]++
+	}
+	if string(chars) != "abcde" {
+		t.Fatalf("Sample modified its input: %s", chars)
+	}
+
+	// we should have seen each sample approx. 100 times
+	if len(sampleCount) != 60 {
+		t.Errorf("saw %v distinct samples, expected 60", len(sampleCount))
+	}
+	for s, n := range sampleCount {
+		if n < 50 || n > 150 {
+			t.Errorf("saw sample %v times: %v", n, s)
+		}
+	}
+
+	// A full sample must be a permutation.
+	full := Sample(chars, len(chars))
+	seen := make(map[byte]bool)
+	for _, c := range full {
+		seen[c] = true
+	}
+	if len(seen) != len(chars) {
+		t.Errorf("full sample is not a permutation: %s", full)
+	}
+}
+
+// BenchmarkShuffle32 benchmarks the speed of Shuffle for small slices.
+func BenchmarkShuffle32(b *testing.B) {
+	s := make([]int, 32)
+	for i := 0; i < b.N; i++ {
+		Shuffle(s)
+	}
+}
+
+// BenchmarkSample benchmarks sampling a few elements from a large slice.
+func BenchmarkSample(b *testing.B) {
+	s := make([]int, 1e6)
+	for i := 0; i < b.N; i++ {
+		Sample(s, 8)
+	}
+}
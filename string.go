@@ -0,0 +1,88 @@
+package fastrand
+
+// Alphabets for String. Each consists of distinct ASCII characters.
+const (
+	// Hex is the lowercase hexadecimal alphabet.
+	Hex = "0123456789abcdef"
+
+	// Base32Crockford is Douglas Crockford's base32 alphabet, which omits
+	// I, L, O and U to avoid confusion with 1, 0 and V.
+	Base32Crockford = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+
+	// Base58 is the Bitcoin base58 alphabet, which omits 0, O, I and l.
+	Base58 = "123456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnopqrstuvwxyz"
+
+	// Base62 is the alphabet of ASCII digits and letters, in ASCII order.
+	Base62 = "0123456789ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz"
+
+	// Base64URL is the URL-safe base64 alphabet of RFC 4648.
+	Base64URL = "ABCDEFGHIJKLMNOPQRSTUVWXYZabcdefghijklmnopqrstuvwxyz0123456789-_"
+
+	// Unambiguous is the alphabet of ASCII digits and letters without 0, 1,
+	// I, O, l and o, which are easily confused when read aloud or in some
+	// fonts.
+	Unambiguous = "23456789ABCDEFGHJKLMNPQRSTUVWXYZabcdefghijkmnpqrstuvwxyz"
+)
+
+// String returns a string of n characters, each chosen uniformly and
+// independently from alphabet. It panics if n < 0, or if alphabet is empty,
+// contains a non-ASCII byte, or contains a repeated character.
+//
+// Characters are extracted from Read output in bulk, one byte per character.
+// A byte b is used only if it is below the largest multiple of len(alphabet)
+// that is at most 256, and is then mapped to alphabet[b%len(alphabet)];
+// otherwise it is discarded. This avoids modulo bias while discarding at most
+// a third of the bytes read.
+func String(alphabet string, n int) string {
+	return (*Generator)(nil).String(alphabet, n)
+}
+
+// String returns a string of n characters, each chosen uniformly and
+// independently from alphabet. See the package-level String.
+func (g *Generator) String(alphabet string, n int) string {
+	if n < 0 {
+		panic("fastrand: negative length passed to String")
+	}
+	checkAlphabet(alphabet)
+	k := len(alphabet)
+	limit := 256 - 256%k
+
+	// Read enough bytes to fill the string most of the time, plus a little
+	// slack for the rejected ones.
+	size := n*256/limit + 8
+	if size > fillChunk {
+		size = fillChunk
+	}
+	buf := make([]byte, size)
+	s := make([]byte, 0, n)
+	for len(s) < n {
+		g.Read(buf)
+		for _, b := range buf {
+			if int(b) < limit {
+				s = append(s, alphabet[int(b)%k])
+				if len(s) == n {
+					break
+				}
+			}
+		}
+	}
+	return string(s)
+}
+
+// checkAlphabet panics if alphabet is not a valid alphabet for String.
+func checkAlphabet(alphabet string) {
+	if len(alphabet) == 0 {
+		panic("fastrand: empty alphabet passed to String")
+	}
+	var seen [128]bool
+	for i := 0; i < len(alphabet); i++ {
+		c := alphabet[i]
+		if c >= 128 {
+			panic("fastrand: alphabet passed to String contains a non-ASCII byte")
+		}
+		if seen[c] {
+			panic("fastrand: alphabet passed to String contains a repeated character")
+		}
+		seen[c] = true
+	}
+}
@@ -0,0 +1,127 @@
+package fastrand
+
+import (
+	"strings"
+	"testing"
+)
+
+// alphabets are the predefined alphabets, with their expected sizes.
+var alphabets = []struct {
+	name     string
+	alphabet string
+	size     int
+}{
+	{"Hex", Hex, 16},
+	{"Base32Crockford", Base32Crockford, 32},
+	{"Base58", Base58, 58},
+	{"Base62", Base62, 62},
+	{"Base64URL", Base64URL, 64},
+	{"Unambiguous", Unambiguous, 56},
+}
+
+// TestAlphabets tests that the predefined alphabets have the expected sizes
+// and are accepted by String.
+func TestAlphabets(t *testing.T) {
+	for _, a := range alphabets {
+		if len(a.alphabet) != a.size {
+			t.Errorf("%v has %v characters, expected %v", a.name, len(a.alphabet), a.size)
+		}
+		if panics(func() { checkAlphabet(a.alphabet) }) {
+			t.Errorf("%v is not a valid alphabet", a.name)
+		}
+	}
+	for _, c := range "01IOlo" {
+		if strings.ContainsRune(Unambiguous, c) {
+			t.Errorf("Unambiguous contains %q", c)
+		}
+	}
+}
+
+// TestString tests that String returns strings of the right length, whose
+// characters are uniform over the alphabet at every position.
+func TestString(t *testing.T) {
+	if String(Hex, 0) != "" {
+		t.Error("expected empty string for n == 0")
+	}
+	if String("x", 5) != "xxxxx" {
+		t.Error("expected repeated character for an alphabet of size 1")
+	}
+	// An alphabet of size 86 rejects the most bytes.
+	worst := Base64URL + "!#$%&()*+,./:;<=>?@[]^"
+	for _, alphabet := range []string{Hex, Base58, Base62, worst, "ab", "abc"} {
+		probs := make([]float64, len(alphabet))
+		for i := range probs {
+			probs[i] = 1 / float64(len(alphabet))
+		}
+		for _, n := range []int{1, 7, 1000} {
+			var s string
+			var pos int
+			checkChiSquare(t, 10000, probs, func() int {
+				if pos == len(s) {
+					s, pos = String(alphabet, n), 0
+					if len(s) != n {
+						t.Fatalf("expected %v characters, got %v", n, len(s))
+					}
+				}
+				pos++
+				return strings.IndexByte(alphabet, s[pos-1])
+			})
+		}
+	}
+}
+
+// TestGeneratorString tests that a seeded Generator's String is
+// reproducible, and uniform over the alphabet.
+func TestGeneratorString(t *testing.T) {
+	a := NewSeededGenerator([]byte("seed")).String(Base58, 100)
+	if b := NewSeededGenerator([]byte("seed")).String(Base58, 100); a != b {
+		t.Error("seeded Generators produced different strings")
+	}
+	g := NewGenerator(Reader)
+	probs := make([]float64, len(Base32Crockford))
+	for i := range probs {
+		probs[i] = 1 / float64(len(probs))
+	}
+	checkChiSquare(t, 10000, probs, func() int {
+		return strings.IndexByte(Base32Crockford, g.String(Base32Crockford, 1)[0])
+	})
+}
+
+// TestStringPanics tests that String panics on invalid arguments.
+func TestStringPanics(t *testing.T) {
+	for _, alphabet := range []string{"", "abca", "ab\xff", "héllo"} {
+		if !panics(func() { String(alphabet, 10) }) {
+			t.Errorf("expected panic for alphabet %q", alphabet)
+		}
+	}
+	if !panics(func() { String(Hex, -1) }) {
+		t.Error("expected panic for n < 0")
+	}
+}
+
+// BenchmarkString32 benchmarks String for a 32-character base62 string.
+func BenchmarkString32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		_ = String(Base62, 32)
+	}
+}
+
+// BenchmarkIntnString32 benchmarks building a 32-character base62 string by
+// calling Intn for each character, as a baseline for BenchmarkString32.
+func BenchmarkIntnString32(b *testing.B) {
+	for i := 0; i < b.N; i++ {
+		s := make([]byte, 32)
+		for j := range s {
+			s[j] = Base62[Intn(len(Base62))]
+		}
+		_ = string(s)
+	}
+}
+
+// BenchmarkString4k benchmarks String for a large base58 string.
+func BenchmarkString4k(b *testing.B) {
+	b.SetBytes(4096)
+	for i := 0; i < b.N; i++ {
+		_ = String(Base58, 4096)
+	}
+}
@@ -0,0 +1,178 @@
+package token
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/NebulousLabs/fastrand"
+)
+
+// panics returns true if the function fn panicked.
+func panics(fn func()) (panicked bool) {
+	defer func() {
+		panicked = (recover() != nil)
+	}()
+	fn()
+	return
+}
+
+// TestEntropy tests that BodyLen provides at least the requested entropy,
+// with no more than one spare character.
+func TestEntropy(t *testing.T) {
+	for bits := 1; bits <= 1024; bits++ {
+		f := Format{Bits: bits}
+		n := f.BodyLen()
+		// 62^n >= 2^bits, and 62^(n-1) < 2^bits.
+		if float64(n)*math.Log2(62) < float64(bits) || float64(n-1)*math.Log2(62) >= float64(bits) {
+			t.Fatalf("BodyLen is %v for %v bits", n, bits)
+		}
+		if e := f.Entropy(); e < float64(bits) || e >= float64(bits)+math.Log2(62) {
+			t.Fatalf("Entropy is %v for %v bits", e, bits)
+		}
+	}
+	if (Format{Bits: 128}).BodyLen() != 22 {
+		t.Error("expected 22 characters for 128 bits")
+	}
+}
+
+// TestCollisions tests that the effective entropy of tokens is their
+// reported entropy, by counting the pairs of equal tokens among many small
+// ones. With N equally likely tokens, k tokens are expected to contain
+// k(k-1)/2N equal pairs, and fewer if some tokens are more likely.
+func TestCollisions(t *testing.T) {
+	f := Format{Prefix: "t_", Bits: 16}
+	const k = 20000
+	seen := make(map[string]int)
+	var pairs int
+	for i := 0; i < k; i++ {
+		s := f.New()
+		pairs += seen[s]
+		seen[s]++
+	}
+	size := math.Exp2(f.Entropy())
+	exp := k * (k - 1) / 2 / size
+	// The number of pairs is approximately Poisson.
+	if math.Abs(float64(pairs)-exp) > 5*math.Sqrt(exp) {
+		t.Errorf("found %v equal pairs, expected %.1f", pairs, exp)
+	}
+}
+
+// TestUniform tests that every character of the body is uniform over the
+// base62 alphabet.
+func TestUniform(t *testing.T) {
+	f := Format{Bits: 128}
+	n := f.BodyLen()
+	counts := make([][62]int, n)
+	const iters = 20000
+	for i := 0; i < iters; i++ {
+		body, err := f.Parse(f.New())
+		if err != nil {
+			t.Fatal(err)
+		}
+		for j := range body {
+			counts[j][strings.IndexByte(alphabet, body[j])]++
+		}
+	}
+	// The critical value of the chi-square distribution with 61 degrees of
+	// freedom at roughly 1e-5.
+	const crit = 121.0
+	e := float64(iters) / 62
+	for j := range counts {
+		var stat float64
+		for _, c := range counts[j] {
+			stat += (float64(c) - e) * (float64(c) - e) / e
+		}
+		if stat > crit {
+			t.Errorf("position %v: chi-square statistic %.2f exceeds %v", j, stat, crit)
+		}
+	}
+}
+
+// TestParse tests that Parse accepts new tokens, and rejects tokens that
+// have been altered.
+func TestParse(t *testing.T) {
+	live := Format{Prefix: "sk_live_", Bits: 128}
+	test := Format{Prefix: "sk_test_", Bits: 128}
+	s := live.New()
+	if len(s) != live.Len() || len(s) != 8+22+6 {
+		t.Fatalf("token %q has the wrong length", s)
+	}
+	if body, err := live.Parse(s); err != nil || body != s[8:30] {
+		t.Fatalf("Parse(%q) = %q, %v", s, body, err)
+	}
+	if err := test.Validate(s); err != ErrPrefix {
+		t.Errorf("expected ErrPrefix for the wrong prefix, got %v", err)
+	}
+	// A token with the other prefix, but the right checksum for this one.
+	if err := test.Validate("sk_test_" + s[8:]); err != ErrChecksum {
+		t.Errorf("expected ErrChecksum for a replaced prefix, got %v", err)
+	}
+	for _, bad := range []string{s[:len(s)-1], s + "0", s[:20] + "!" + s[21:]} {
+		if err := live.Validate(bad); err != ErrMalformed {
+			t.Errorf("expected ErrMalformed for %q, got %v", bad, err)
+		}
+	}
+	// CRC32 detects every change to a single character.
+	for i := 8; i < len(s); i++ {
+		for _, c := range []byte(alphabet) {
+			if c == s[i] {
+				continue
+			}
+			bad := s[:i] + string(c) + s[i+1:]
+			if err := live.Validate(bad); err != ErrChecksum {
+				t.Fatalf("expected ErrChecksum for %q, got %v", bad, err)
+			}
+		}
+	}
+	// And every transposition of adjacent characters.
+	for i := 8; i+1 < len(s); i++ {
+		if s[i] == s[i+1] {
+			continue
+		}
+		bad := s[:i] + string(s[i+1]) + string(s[i]) + s[i+2:]
+		if err := live.Validate(bad); err != ErrChecksum {
+			t.Fatalf("expected ErrChecksum for %q, got %v", bad, err)
+		}
+	}
+}
+
+// TestSrc tests that tokens are determined by a seeded Src.
+func TestSrc(t *testing.T) {
+	a := Format{Bits: 64, Src: fastrand.NewSeededGenerator([]byte("seed"))}
+	b := Format{Bits: 64, Src: fastrand.NewSeededGenerator([]byte("seed"))}
+	if a.New() != b.New() {
+		t.Error("seeded Formats produced different tokens")
+	}
+}
+
+// TestPanics tests that New panics on an invalid Format.
+func TestPanics(t *testing.T) {
+	for _, f := range []Format{
+		{Bits: 0},
+		{Bits: -8},
+		{Prefix: "sk live ", Bits: 128},
+		{Prefix: "sk/", Bits: 128},
+	} {
+		if !panics(func() { f.New() }) {
+			t.Errorf("expected panic for %+v", f)
+		}
+	}
+}
+
+// BenchmarkNew benchmarks New with 128 bits of entropy.
+func BenchmarkNew(b *testing.B) {
+	f := Format{Prefix: "sk_live_", Bits: 128}
+	for i := 0; i < b.N; i++ {
+		_ = f.New()
+	}
+}
+
+// BenchmarkValidate benchmarks Validate with 128 bits of entropy.
+func BenchmarkValidate(b *testing.B) {
+	f := Format{Prefix: "sk_live_", Bits: 128}
+	s := f.New()
+	for i := 0; i < b.N; i++ {
+		_ = f.Validate(s)
+	}
+}
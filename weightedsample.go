@@ -0,0 +1,133 @@
+package fastrand
+
+import (
+	"container/heap"
+	"math"
+	"sort"
+	"strconv"
+)
+
+// A keyedIndex is an index with an Efraimidis-Spirakis key. Keys are stored
+// as logarithms, log(u)/w, so that they do not underflow for small weights.
+type keyedIndex struct {
+	index int
+	key   float64
+}
+
+// keyHeap is a min-heap of keyedIndexes, ordered by key.
+type keyHeap []keyedIndex
+
+func (h keyHeap) Len() int           { return len(h) }
+func (h keyHeap) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *keyHeap) Push(x any)        { *h = append(*h, x.(keyedIndex)) }
+func (h *keyHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// indices returns the indices in h, ordered from largest to smallest key.
+func (h keyHeap) indices() []int {
+	sort.Slice(h, func(i, j int) bool { return h[i].key > h[j].key })
+	out := make([]int, len(h))
+	for i, ki := range h {
+		out[i] = ki.index
+	}
+	return out
+}
+
+// logUniform returns log(u) for a uniform random u in (0,1].
+func logUniform() float64 {
+	return math.Log(1 - Float64())
+}
+
+// checkWeight panics if w is not a valid weight.
+func checkWeight(w float64) {
+	if !(w >= 0) || math.IsInf(w, 0) {
+		panic("fastrand: invalid weight " + strconv.FormatFloat(w, 'g', -1, 64))
+	}
+}
+
+// WeightedSample returns k distinct indices into weights, where each index is
+// chosen with probability proportional to its weight among the indices not
+// yet chosen. The indices are returned in the order they would have been
+// chosen. It panics if k < 0, if any weight is negative or not finite, or if
+// fewer than k weights are positive.
+//
+// WeightedSample uses the A-Res method of Efraimidis and Spirakis: each index
+// is assigned the key u^(1/w) for a uniform random u, and the k indices with
+// the largest keys are returned.
+func WeightedSample(weights []float64, k int) []int {
+	if k < 0 {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k))
+	}
+	h := make(keyHeap, 0, k)
+	var positive int
+	for i, w := range weights {
+		checkWeight(w)
+		if w == 0 {
+			continue
+		}
+		positive++
+		key := logUniform() / w
+		if len(h) < k {
+			heap.Push(&h, keyedIndex{i, key})
+		} else if k > 0 && key > h[0].key {
+			h[0] = keyedIndex{i, key}
+			heap.Fix(&h, 0)
+		}
+	}
+	if positive < k {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k) + " for " + strconv.Itoa(positive) + " positive weights")
+	}
+	return h.indices()
+}
+
+// WeightedSampleSeq is like WeightedSample, but consumes weights from a
+// sequence of unknown length, using O(k) memory. The returned indices are
+// positions in the sequence. If the sequence yields fewer than k positive
+// weights, the indices of all of them are returned. It panics if k < 0 or if
+// any weight is negative or not finite.
+//
+// weights is a push iterator with the underlying type of iter.Seq[float64],
+// so sequences such as those returned by slices.Values can be passed
+// directly.
+//
+// WeightedSampleSeq uses the A-ExpJ method of Efraimidis and Spirakis, which
+// draws a random number only when an item enters the sample, rather than for
+// every item.
+func WeightedSampleSeq(weights func(yield func(float64) bool), k int) []int {
+	if k < 0 {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k))
+	}
+	h := make(keyHeap, 0, k)
+	var jump float64 // remaining weight to skip before the next insertion
+	i := 0
+	weights(func(w float64) bool {
+		checkWeight(w)
+		switch {
+		case w == 0 || k == 0:
+		case len(h) < k:
+			heap.Push(&h, keyedIndex{i, logUniform() / w})
+			if len(h) == k {
+				jump = logUniform() / h[0].key
+			}
+		default:
+			jump -= w
+			if jump > 0 {
+				break
+			}
+			// The item enters the sample. Its key is drawn from the
+			// portion of the distribution that exceeds the threshold.
+			tw := math.Exp(w * h[0].key)
+			h[0] = keyedIndex{i, math.Log(tw+(1-tw)*(1-Float64())) / w}
+			heap.Fix(&h, 0)
+			jump = logUniform() / h[0].key
+		}
+		i++
+		return true
+	})
+	return h.indices()
+}
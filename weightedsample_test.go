@@ -0,0 +1,128 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// successiveSampling returns the probability of drawing each ordered pair
+// (a, b), indexed by a*len(weights)+b, when drawing two distinct indices in
+// proportion to weights.
+func successiveSampling(weights []float64) []float64 {
+	var total float64
+	for _, w := range weights {
+		total += w
+	}
+	n := len(weights)
+	probs := make([]float64, n*n)
+	for a := range weights {
+		for b := range weights {
+			if a != b {
+				probs[a*n+b] = weights[a] / total * weights[b] / (total - weights[a])
+			}
+		}
+	}
+	return probs
+}
+
+// values returns a sequence of the elements of s, for WeightedSampleSeq.
+func values(s []float64) func(yield func(float64) bool) {
+	return func(yield func(float64) bool) {
+		for _, w := range s {
+			if !yield(w) {
+				return
+			}
+		}
+	}
+}
+
+// checkInclusion draws iters samples of two indices from fn, and fails the
+// test if the order of the draws or the rate at which each index is included
+// is not consistent with successive sampling from weights.
+func checkInclusion(t *testing.T, iters int, weights []float64, fn func() []int) {
+	t.Helper()
+	n := len(weights)
+	pairs := successiveSampling(weights)
+	included := make([]int, n)
+	checkChiSquare(t, iters, pairs, func() int {
+		s := fn()
+		if len(s) != 2 || s[0] == s[1] {
+			t.Fatalf("expected two distinct indices, got %v", s)
+		}
+		included[s[0]]++
+		included[s[1]]++
+		return s[0]*n + s[1]
+	})
+	for i := range weights {
+		var p float64
+		for j := range weights {
+			p += pairs[i*n+j] + pairs[j*n+i]
+		}
+		exp := p * float64(iters)
+		if sd := math.Sqrt(exp * (1 - p)); math.Abs(float64(included[i])-exp) > 5*sd {
+			t.Errorf("index %v included %v times, expected %.0f", i, included[i], exp)
+		}
+	}
+}
+
+// TestWeightedSamplePanics tests that WeightedSample and WeightedSampleSeq
+// panic on invalid arguments.
+func TestWeightedSamplePanics(t *testing.T) {
+	if !panics(func() { WeightedSample([]float64{1, 2}, -1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if !panics(func() { WeightedSample([]float64{1, 0}, 2) }) {
+		t.Error("expected panic for k > number of positive weights")
+	}
+	if !panics(func() { WeightedSample([]float64{1, -1}, 1) }) {
+		t.Error("expected panic for negative weight")
+	}
+	if !panics(func() { WeightedSampleSeq(values([]float64{1, math.NaN()}), 1) }) {
+		t.Error("expected panic for NaN weight")
+	}
+	if !panics(func() { WeightedSampleSeq(values([]float64{1}), -1) }) {
+		t.Error("expected panic for k < 0")
+	}
+	if s := WeightedSampleSeq(values([]float64{1, 0}), 2); len(s) != 1 || s[0] != 0 {
+		t.Errorf("expected only the positive weight, got %v", s)
+	}
+	if s := WeightedSample([]float64{1, 2}, 0); len(s) != 0 {
+		t.Errorf("expected empty sample, got %v", s)
+	}
+}
+
+// TestWeightedSample tests the inclusion probabilities of WeightedSample.
+func TestWeightedSample(t *testing.T) {
+	weights := []float64{1, 2, 3, 4, 0}
+	checkInclusion(t, 100000, weights, func() []int { return WeightedSample(weights, 2) })
+
+	// Tiny weights must not underflow to equal keys.
+	tiny := []float64{1e-300, 3e-300}
+	checkInclusion(t, 10000, tiny, func() []int { return WeightedSample(tiny, 2) })
+}
+
+// TestWeightedSampleSeq tests the inclusion probabilities of
+// WeightedSampleSeq, using a stream long enough that most items are skipped.
+func TestWeightedSampleSeq(t *testing.T) {
+	weights := []float64{1, 2, 3, 4, 0}
+	checkInclusion(t, 100000, weights, func() []int { return WeightedSampleSeq(values(weights), 2) })
+
+	long := make([]float64, 20)
+	for i := range long {
+		long[i] = float64(i%4 + 1)
+	}
+	checkInclusion(t, 50000, long, func() []int { return WeightedSampleSeq(values(long), 2) })
+}
+
+// BenchmarkWeightedSampleSeq benchmarks sampling a few indices from a long
+// stream of weights.
+func BenchmarkWeightedSampleSeq(b *testing.B) {
+	weights := make([]float64, 1e4)
+	for i := range weights {
+		weights[i] = float64(i%10 + 1)
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		WeightedSampleSeq(values(weights), 8)
+	}
+}
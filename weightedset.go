@@ -0,0 +1,185 @@
+package fastrand
+
+import (
+	"math/bits"
+	"strconv"
+)
+
+// A WeightedSet is a set of keys with associated weights, from which keys can
+// be drawn in proportion to their weight. Unlike an AliasTable, weights can be
+// added, updated and removed in O(log n) time, and draws take O(log n) time.
+// The zero value is an empty set ready to use. A WeightedSet is not safe for
+// concurrent use.
+//
+// Draws are exact: a key with weight w is drawn with probability exactly
+// w/Total().
+type WeightedSet[K comparable] struct {
+	keys    []K
+	weights []uint64
+	index   map[K]int
+	total   uint64
+	nonzero int // number of keys with positive weight
+
+	// tree is a Fenwick tree over weights. With 1-based positions, tree[i-1]
+	// holds the sum of the weights at positions (i-lowbit(i), i].
+	tree []uint64
+}
+
+// Len returns the number of keys in the set.
+func (ws *WeightedSet[K]) Len() int { return len(ws.keys) }
+
+// Total returns the sum of the weights of all keys in the set.
+func (ws *WeightedSet[K]) Total() uint64 { return ws.total }
+
+// Contains reports whether key is in the set.
+func (ws *WeightedSet[K]) Contains(key K) bool {
+	_, ok := ws.index[key]
+	return ok
+}
+
+// Weight returns the weight of key, or 0 if key is not in the set.
+func (ws *WeightedSet[K]) Weight(key K) uint64 {
+	if i, ok := ws.index[key]; ok {
+		return ws.weights[i]
+	}
+	return 0
+}
+
+// Add adds key to the set with the given weight. It panics if key is already
+// in the set, or if the total weight would overflow a uint64.
+func (ws *WeightedSet[K]) Add(key K, weight uint64) {
+	if _, ok := ws.index[key]; ok {
+		panic("fastrand: key passed to WeightedSet.Add is already present")
+	}
+	if ws.total+weight < ws.total {
+		panic("fastrand: total weight of WeightedSet overflows")
+	}
+	if ws.index == nil {
+		ws.index = make(map[K]int)
+	}
+	ws.index[key] = len(ws.keys)
+	ws.keys = append(ws.keys, key)
+	ws.weights = append(ws.weights, weight)
+
+	// The new node covers (p-lowbit(p), p], which is its own weight plus the
+	// tail of the existing positions.
+	p := len(ws.keys)
+	lowbit := p & -p
+	ws.tree = append(ws.tree, weight+ws.prefix(p-1)-ws.prefix(p-lowbit))
+	ws.total += weight
+	if weight > 0 {
+		ws.nonzero++
+	}
+}
+
+// Update sets the weight of key. It panics if key is not in the set, or if
+// the total weight would overflow a uint64.
+func (ws *WeightedSet[K]) Update(key K, weight uint64) {
+	i, ok := ws.index[key]
+	if !ok {
+		panic("fastrand: key passed to WeightedSet.Update is not present")
+	}
+	if ws.total-ws.weights[i]+weight < ws.total-ws.weights[i] {
+		panic("fastrand: total weight of WeightedSet overflows")
+	}
+	ws.set(i, weight)
+}
+
+// Remove removes key from the set. It panics if key is not in the set.
+func (ws *WeightedSet[K]) Remove(key K) {
+	i, ok := ws.index[key]
+	if !ok {
+		panic("fastrand: key passed to WeightedSet.Remove is not present")
+	}
+	// Move the last key into position i, then drop the last position. Once
+	// its weight is zero, no remaining node of the tree depends on it.
+	last := len(ws.keys) - 1
+	lastKey, lastWeight := ws.keys[last], ws.weights[last]
+	ws.set(last, 0)
+	if i != last {
+		ws.set(i, lastWeight)
+		ws.keys[i] = lastKey
+		ws.index[lastKey] = i
+	}
+	delete(ws.index, key)
+	ws.keys = ws.keys[:last]
+	ws.weights = ws.weights[:last]
+	ws.tree = ws.tree[:last]
+}
+
+// Sample returns a random key, chosen in proportion to its weight. It panics
+// if the total weight is zero.
+func (ws *WeightedSet[K]) Sample() K {
+	if ws.total == 0 {
+		panic("fastrand: Sample called on WeightedSet with no weight")
+	}
+	return ws.keys[ws.search(Uint64n(ws.total))]
+}
+
+// SampleWithoutReplacement returns k distinct keys, chosen by repeatedly
+// drawing a key in proportion to its weight and then excluding it from
+// subsequent draws. The keys are returned in the order they were drawn. It
+// panics if k < 0 or if fewer than k keys have positive weight.
+func (ws *WeightedSet[K]) SampleWithoutReplacement(k int) []K {
+	if k < 0 || k > ws.nonzero {
+		panic("fastrand: invalid sample size " + strconv.Itoa(k) + " for WeightedSet with " + strconv.Itoa(ws.nonzero) + " positive weights")
+	}
+	out := make([]K, k)
+	drawn := make([]int, k)
+	for j := range out {
+		i := ws.search(Uint64n(ws.total))
+		out[j], drawn[j] = ws.keys[i], i
+		// Temporarily exclude the key by stashing its weight.
+		w := ws.weights[i]
+		ws.set(i, 0)
+		ws.weights[i] = w
+	}
+	// Restore the excluded weights.
+	for _, i := range drawn {
+		w := ws.weights[i]
+		ws.weights[i] = 0
+		ws.set(i, w)
+	}
+	return out
+}
+
+// set changes the weight at position i, updating the tree and totals.
+func (ws *WeightedSet[K]) set(i int, weight uint64) {
+	old := ws.weights[i]
+	if old > 0 {
+		ws.nonzero--
+	}
+	if weight > 0 {
+		ws.nonzero++
+	}
+	ws.weights[i] = weight
+	ws.total = ws.total - old + weight
+	// Deltas are applied modulo 2^64, which is exact since every true sum
+	// fits in a uint64.
+	delta := weight - old
+	for p := i + 1; p <= len(ws.tree); p += p & -p {
+		ws.tree[p-1] += delta
+	}
+}
+
+// prefix returns the sum of the weights at positions [0,p).
+func (ws *WeightedSet[K]) prefix(p int) uint64 {
+	var sum uint64
+	for ; p > 0; p -= p & -p {
+		sum += ws.tree[p-1]
+	}
+	return sum
+}
+
+// search returns the smallest position i such that the sum of the weights at
+// positions [0,i] exceeds u. u must be less than the total weight.
+func (ws *WeightedSet[K]) search(u uint64) int {
+	pos := 0
+	for step := 1 << (bits.Len(uint(len(ws.tree))) - 1); step > 0; step >>= 1 {
+		if next := pos + step; next <= len(ws.tree) && ws.tree[next-1] <= u {
+			pos = next
+			u -= ws.tree[next-1]
+		}
+	}
+	return pos
+}
@@ -0,0 +1,151 @@
+package fastrand
+
+import (
+	"math"
+	"testing"
+)
+
+// checkWeightedSet verifies the internal consistency of ws against a naive
+// computation of its prefix sums.
+func checkWeightedSet[K comparable](t *testing.T, ws *WeightedSet[K]) {
+	t.Helper()
+	var sum uint64
+	var nonzero int
+	for i, key := range ws.keys {
+		if ws.index[key] != i {
+			t.Fatalf("key %v has index %v, expected %v", key, ws.index[key], i)
+		}
+		if ws.prefix(i) != sum {
+			t.Fatalf("prefix(%v) = %v, expected %v", i, ws.prefix(i), sum)
+		}
+		sum += ws.weights[i]
+		if ws.weights[i] > 0 {
+			nonzero++
+		}
+	}
+	if len(ws.index) != len(ws.keys) || sum != ws.total || nonzero != ws.nonzero {
+		t.Fatalf("inconsistent set: %v keys, %v indices, total %v (expected %v), nonzero %v (expected %v)",
+			len(ws.keys), len(ws.index), ws.total, sum, ws.nonzero, nonzero)
+	}
+}
+
+// TestWeightedSetPanics tests that WeightedSet panics on misuse.
+func TestWeightedSetPanics(t *testing.T) {
+	var ws WeightedSet[string]
+	if !panics(func() { ws.Sample() }) {
+		t.Error("expected panic for empty set")
+	}
+	ws.Add("a", 0)
+	if !panics(func() { ws.Sample() }) {
+		t.Error("expected panic for zero total weight")
+	}
+	if !panics(func() { ws.Add("a", 1) }) {
+		t.Error("expected panic for duplicate key")
+	}
+	if !panics(func() { ws.Update("b", 1) }) {
+		t.Error("expected panic for updating missing key")
+	}
+	if !panics(func() { ws.Remove("b") }) {
+		t.Error("expected panic for removing missing key")
+	}
+	if !panics(func() { ws.SampleWithoutReplacement(1) }) {
+		t.Error("expected panic for k > number of positive weights")
+	}
+	ws.Add("b", math.MaxUint64)
+	if !panics(func() { ws.Add("c", 1) }) {
+		t.Error("expected panic for overflowing total weight")
+	}
+	if !panics(func() { ws.Update("a", 1) }) {
+		t.Error("expected panic for overflowing total weight")
+	}
+	if panics(func() { ws.Update("b", 1) }) {
+		t.Error("did not expect panic for valid update")
+	}
+}
+
+// TestWeightedSetOperations applies random operations to a WeightedSet and
+// checks its consistency after each one.
+func TestWeightedSetOperations(t *testing.T) {
+	var ws WeightedSet[int]
+	for i := 0; i < 2000; i++ {
+		key := Intn(64)
+		switch {
+		case !ws.Contains(key):
+			ws.Add(key, Uint64n(100))
+		case Intn(2) == 0:
+			ws.Update(key, Uint64n(100))
+		default:
+			ws.Remove(key)
+			if ws.Contains(key) || ws.Weight(key) != 0 {
+				t.Fatalf("key %v still present after removal", key)
+			}
+		}
+		checkWeightedSet(t, &ws)
+	}
+	for ws.Len() > 0 {
+		ws.Remove(ws.keys[Intn(ws.Len())])
+		checkWeightedSet(t, &ws)
+	}
+}
+
+// TestWeightedSetSample tests that Sample draws keys in proportion to their
+// weights, including after updates and removals.
+func TestWeightedSetSample(t *testing.T) {
+	var ws WeightedSet[int]
+	for i := 0; i < 8; i++ {
+		ws.Add(i, uint64(i))
+	}
+	ws.Update(0, 4)
+	ws.Update(7, 0)
+	ws.Remove(3)
+	// Weights are now 4, 1, 2, -, 4, 5, 6, 0 with a total of 22.
+	probs := []float64{4.0 / 22, 1.0 / 22, 2.0 / 22, 0, 4.0 / 22, 5.0 / 22, 6.0 / 22, 0}
+	checkChiSquare(t, 100000, probs, ws.Sample)
+}
+
+// TestWeightedSetSampleWithoutReplacement tests that
+// SampleWithoutReplacement draws ordered pairs with the probabilities of
+// successive weighted sampling, and leaves the set unchanged.
+func TestWeightedSetSampleWithoutReplacement(t *testing.T) {
+	var ws WeightedSet[int]
+	weights := []uint64{1, 2, 3, 0}
+	for i, w := range weights {
+		ws.Add(i, w)
+	}
+
+	// P(a, b) = w_a/6 * w_b/(6-w_a) for the pairs of positive weights.
+	probs := make([]float64, 9)
+	for a := 0; a < 3; a++ {
+		for b := 0; b < 3; b++ {
+			if a != b {
+				wa, wb := float64(weights[a]), float64(weights[b])
+				probs[a*3+b] = wa / 6 * wb / (6 - wa)
+			}
+		}
+	}
+	checkChiSquare(t, 60000, probs, func() int {
+		s := ws.SampleWithoutReplacement(2)
+		return s[0]*3 + s[1]
+	})
+	checkWeightedSet(t, &ws)
+	if ws.Total() != 6 {
+		t.Errorf("expected total of 6 after sampling, got %v", ws.Total())
+	}
+
+	all := ws.SampleWithoutReplacement(3)
+	if len(all) != 3 || all[0] == all[1] || all[0] == all[2] || all[1] == all[2] {
+		t.Errorf("expected three distinct keys, got %v", all)
+	}
+}
+
+// BenchmarkWeightedSetSample benchmarks drawing from a large WeightedSet.
+func BenchmarkWeightedSetSample(b *testing.B) {
+	var ws WeightedSet[int]
+	for i := 0; i < 1000; i++ {
+		ws.Add(i, uint64(i+1))
+	}
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_ = ws.Sample()
+	}
+}